@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	packagePath = "github.com/cdnnow-pro/go-tracer"
+
+	// maxOrphanStackFrames limits the stack attached to orphan spans.
+	maxOrphanStackFrames = 10
+)
+
+// WithPropagationAudit enables the debug mode that detects broken context
+// propagation: a span started without a parent on a goroutine that is running
+// (or was spawned by a goroutine running) a traced operation is reported with the
+// call site, since it most likely means a fresh context was used mid-request.
+//
+// It's expensive (parses goroutine stacks on every span start), don't enable it
+// in production.
+func WithPropagationAudit() Option {
+	return func(opts *Options) {
+		opts.propagationAudit = true
+	}
+}
+
+// WithOrphanSpanStacks tags spans that unexpectedly become local roots (detected
+// the same way as with WithPropagationAudit) with the trimmed stack of the
+// goroutine ("code.stacktrace") and the location it was created at
+// ("orphan.created_by"), to find where a fresh context.Background() was used
+// mid-request.
+//
+// It's as expensive as WithPropagationAudit.
+func WithOrphanSpanStacks() Option {
+	return func(opts *Options) {
+		opts.orphanSpanStacks = true
+	}
+}
+
+var (
+	auditMu sync.Mutex
+	// auditActive counts spans in progress per goroutine ID.
+	auditActive = make(map[uint64]int)
+)
+
+// auditStart checks the span being started with ctx and marks the goroutine as
+// running a traced operation. Returns the goroutine ID to pass to auditEnd and
+// attributes for the span if it's an orphan.
+func auditStart(ctx context.Context, name string, options Options) (uint64, []attribute.KeyValue) {
+	g := currentGoroutine()
+
+	auditMu.Lock()
+	inRequest := auditActive[g.id] > 0 || (g.creator != 0 && auditActive[g.creator] > 0)
+	auditActive[g.id]++
+	auditMu.Unlock()
+
+	if !inRequest || trace.SpanContextFromContext(ctx).IsValid() {
+		return g.id, nil
+	}
+
+	if options.propagationAudit {
+		slog.WarnContext(ctx, "tracer: span started without parent inside traced operation",
+			slog.String("span", name),
+			slog.String("caller", callSite()),
+		)
+	}
+	if options.orphanSpanStacks {
+		return g.id, g.orphanAttributes()
+	}
+
+	return g.id, nil
+}
+
+func auditEnd(id uint64) {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	if auditActive[id]--; auditActive[id] <= 0 {
+		delete(auditActive, id)
+	}
+}
+
+// goroutineStack is the stack of the current goroutine.
+type goroutineStack struct {
+	id      uint64
+	creator uint64 // 0 if unknown
+	stack   []byte
+}
+
+// currentGoroutine returns the stack of the current goroutine with its ID and
+// the ID of the goroutine that created it parsed.
+func currentGoroutine() goroutineStack {
+	buf := make([]byte, 16<<10) //nolint:mnd
+	g := goroutineStack{stack: buf[:runtime.Stack(buf, false)]}
+
+	// goroutine 42 [running]:
+	if line, _, ok := bytes.Cut(bytes.TrimPrefix(g.stack, []byte("goroutine ")), []byte(" ")); ok {
+		g.id, _ = strconv.ParseUint(string(line), 10, 64)
+	}
+	// created by example.com/pkg.fn in goroutine 7
+	if i := bytes.LastIndex(g.stack, []byte(" in goroutine ")); i >= 0 {
+		rest := g.stack[i+len(" in goroutine "):]
+		if j := bytes.IndexByte(rest, '\n'); j >= 0 {
+			rest = rest[:j]
+		}
+		g.creator, _ = strconv.ParseUint(string(rest), 10, 64)
+	}
+
+	return g
+}
+
+// orphanAttributes returns the stack trimmed to frames outside of this package
+// and the goroutine creation location.
+func (g goroutineStack) orphanAttributes() []attribute.KeyValue {
+	lines := strings.Split(strings.TrimSpace(string(g.stack)), "\n")
+
+	var (
+		frames    []string
+		createdBy string
+	)
+	// Skip the header, then every frame is a function line and a file line.
+	for i := 1; i+1 < len(lines); i += 2 {
+		function, file := lines[i], lines[i+1]
+		switch {
+		case strings.HasPrefix(function, "created by "):
+			createdBy = function + "\n" + file
+		case strings.HasPrefix(function, packagePath+"."):
+		case len(frames) < maxOrphanStackFrames*2:
+			frames = append(frames, function, file)
+		}
+	}
+
+	attrs := []attribute.KeyValue{semconv.CodeStacktrace(strings.Join(frames, "\n"))}
+	if createdBy != "" {
+		attrs = append(attrs, attribute.String("orphan.created_by", createdBy))
+	}
+	return attrs
+}
+
+// callSite returns the location of the first caller outside of this package.
+func callSite() string {
+	pcs := make([]uintptr, 32)   //nolint:mnd
+	n := runtime.Callers(2, pcs) //nolint:mnd
+
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePath+".") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+)
+
+// captureLogs makes the slog default logger write to the returned buffer for
+// the test.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
+
+func TestPropagationAudit(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    func(ctx context.Context)
+		reported bool
+	}{
+		{"child", func(ctx context.Context) {
+			_, span := StartSpan(ctx, "orphan")
+			span.End()
+		}, false},
+		{"fresh context", func(context.Context) {
+			_, span := StartSpan(context.Background(), "orphan")
+			span.End()
+		}, true},
+		{"fresh context in spawned goroutine", func(context.Context) {
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, span := StartSpan(context.Background(), "orphan")
+				span.End()
+			}()
+			wg.Wait()
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestTracer(t, WithPropagationAudit())
+			logs := captureLogs(t)
+
+			ctx, span := StartSpan(context.Background(), "request")
+			tt.start(ctx)
+			span.End()
+
+			if reported := strings.Contains(logs.String(), "span=orphan"); reported != tt.reported {
+				t.Errorf("reported = %v, want %v, logs: %s", reported, tt.reported, logs)
+			}
+		})
+	}
+}
+
+func TestPropagationAuditOutsideOperation(t *testing.T) {
+	initTestTracer(t, WithPropagationAudit())
+	logs := captureLogs(t)
+
+	// Sequential roots aren't orphans.
+	for range 2 {
+		_, span := StartSpan(context.Background(), "request")
+		span.End()
+	}
+
+	if logs.Len() != 0 {
+		t.Errorf("logs = %s, want none", logs)
+	}
+}
+
+func TestOrphanSpanStacks(t *testing.T) {
+	recorder := initTestTracer(t, WithOrphanSpanStacks())
+
+	ctx, span := StartSpan(context.Background(), "request")
+	_, child := StartSpan(ctx, "child")
+	child.End()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, orphan := StartSpan(context.Background(), "orphan")
+		orphan.End()
+	}()
+	wg.Wait()
+	span.End()
+
+	for _, s := range recorder.Ended() {
+		stack, tagged := spanAttribute(s, semconv.CodeStacktraceKey)
+		createdBy, _ := spanAttribute(s, "orphan.created_by")
+		if want := s.Name() == "orphan"; tagged != want {
+			t.Errorf("%s tagged = %v, want %v", s.Name(), tagged, want)
+			continue
+		}
+		if !tagged {
+			continue
+		}
+		if strings.Contains(stack.AsString(), packagePath+".auditStart") {
+			t.Errorf("stack has frames of the package: %s", stack.AsString())
+		}
+		if !strings.Contains(createdBy.AsString(), "TestOrphanSpanStacks") {
+			t.Errorf("orphan.created_by = %q, want the test function", createdBy.AsString())
+		}
+	}
+}
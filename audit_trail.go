@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/cdnnow-pro/go-tracer/keys"
+)
+
+const (
+	// auditQueueSize is the queue size of the audit trail route.
+	auditQueueSize = 8192
+	// auditRetryTime is how long the audit trail export of a batch is retried.
+	auditRetryTime = 5 * time.Minute
+)
+
+// WithAuditTrail additionally exports spans tagged with keys.Audit to the OTLP
+// gRPC collector at endpoint ("host:port") with headers, e.g. to store audit
+// events with a retention different from debug traces. The TLS settings and the
+// attribute encryption (WithAttributeEncryption) are the same as for the main
+// collector.
+//
+// The audit route never drops spans: when its queue is full, ending an audit span
+// blocks until there is room, and failed exports are retried for 5 minutes.
+//
+//	keys.Audit.Set(span, true)
+func WithAuditTrail(endpoint string, headers map[string]string) Option {
+	return func(opts *Options) {
+		opts.auditTrail = &auditTrail{endpoint: endpoint, headers: headers}
+	}
+}
+
+type auditTrail struct {
+	endpoint string
+	headers  map[string]string
+}
+
+// newAuditTrailProcessor creates the processor exporting audit spans to the
+// audit trail collector.
+func newAuditTrailProcessor(ctx context.Context, options Options) (tracesdk.SpanProcessor, error) {
+	tlsConfig, err := clientTLSConfig(options)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(options.auditTrail.endpoint),
+		otlptracegrpc.WithHeaders(options.auditTrail.headers),
+		otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{
+			Enabled:         true,
+			InitialInterval: time.Second,
+			MaxInterval:     30 * time.Second, //nolint:mnd
+			MaxElapsedTime:  auditRetryTime,
+		}),
+		otlptracegrpc.WithTimeout(auditRetryTime),
+	}
+	if tlsConfig != nil {
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit trail exporter: %w", err)
+	}
+
+	var processor tracesdk.SpanProcessor = tracesdk.NewBatchSpanProcessor(exporter,
+		tracesdk.WithBlocking(),
+		tracesdk.WithMaxQueueSize(auditQueueSize),
+		tracesdk.WithExportTimeout(auditRetryTime),
+	)
+	if options.encryption != nil {
+		processor = encryptionProcessor{next: processor, enc: options.encryption}
+	}
+	return auditTrailProcessor{SpanProcessor: processor}, nil
+}
+
+// auditTrailProcessor passes only audit spans to the next processor.
+type auditTrailProcessor struct {
+	tracesdk.SpanProcessor
+}
+
+func (p auditTrailProcessor) OnEnd(s tracesdk.ReadOnlySpan) {
+	if isAudit(s) {
+		p.SpanProcessor.OnEnd(s)
+	}
+}
+
+func isAudit(s tracesdk.ReadOnlySpan) bool {
+	for _, kv := range s.Attributes() {
+		if kv.Key == keys.Audit.Key() {
+			return kv.Value.AsBool()
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/cdnnow-pro/go-tracer/keys"
+)
+
+func TestAuditTrailProcessorEncryption(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		encrypted bool
+	}{
+		{"plain", nil, false},
+		{"encrypted", []Option{WithAttributeEncryption("k1", make([]byte, 16), "user.id")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := buildOptions(append(tt.opts, WithAuditTrail("localhost:4317", nil)))
+			p, err := newAuditTrailProcessor(context.Background(), options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+
+			_, encrypted := p.(auditTrailProcessor).SpanProcessor.(encryptionProcessor)
+			if encrypted != tt.encrypted {
+				t.Errorf("encrypted = %v, want %v", encrypted, tt.encrypted)
+			}
+		})
+	}
+}
+
+// traceCollector is the fake OTLP gRPC collector recording exported span names
+// and the request metadata.
+type traceCollector struct {
+	coltracepb.UnimplementedTraceServiceServer
+
+	mu    sync.Mutex
+	spans []string
+	md    metadata.MD
+}
+
+func (c *traceCollector) Export(
+	ctx context.Context, req *coltracepb.ExportTraceServiceRequest,
+) (*coltracepb.ExportTraceServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.md, _ = metadata.FromIncomingContext(ctx)
+	for _, rs := range req.GetResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			for _, s := range ss.GetSpans() {
+				c.spans = append(c.spans, s.GetName())
+			}
+		}
+	}
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+// startTraceCollector starts the collector and returns its address.
+func startTraceCollector(t *testing.T) (*traceCollector, string) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return serveTraceCollector(t, lis), lis.Addr().String()
+}
+
+// serveTraceCollector serves the collector on lis.
+func serveTraceCollector(t *testing.T, lis net.Listener) *traceCollector {
+	t.Helper()
+
+	collector := &traceCollector{}
+	server := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(server, collector)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	return collector
+}
+
+func TestAuditTrail(t *testing.T) {
+	collector, addr := startTraceCollector(t)
+
+	tr, err := New(context.Background(), "app", "1.0.0", WithExporter(tracetest.NewNoopExporter()), WithAlwaysSample(),
+		WithAuditTrail(addr, map[string]string{"x-audit-key": "secret"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := tr.StartSpan(context.Background(), "debug")
+	span.End()
+	_, span = tr.StartSpan(context.Background(), "login")
+	keys.Audit.Set(span, true)
+	span.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := tr.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	if len(collector.spans) != 1 || collector.spans[0] != "login" {
+		t.Errorf("audit spans = %v, want [login]", collector.spans)
+	}
+	if got := collector.md.Get("x-audit-key"); len(got) != 1 || got[0] != "secret" {
+		t.Errorf("x-audit-key = %v, want [secret]", got)
+	}
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// SetBaggage returns the context carrying the baggage member, propagated to
+// downstream services with the W3C Baggage header. Use it for request-scoped
+// metadata (tenant ID, experiment flags); keep it small, since it's sent with
+// every outgoing request. Invalid members are reported to the OpenTelemetry
+// error handler and ctx is returned unchanged.
+func SetBaggage(ctx context.Context, key, value string) context.Context {
+	member, err := baggage.NewMemberRaw(key, value)
+	if err != nil {
+		otel.Handle(fmt.Errorf("tracer: invalid baggage member %q: %w", key, err))
+		return ctx
+	}
+	bag, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		otel.Handle(fmt.Errorf("tracer: failed to set baggage member %q: %w", key, err))
+		return ctx
+	}
+	return baggage.ContextWithBaggage(ctx, bag)
+}
+
+// Baggage returns the value of the ctx baggage member, empty if there is none.
+func Baggage(ctx context.Context, key string) string {
+	return baggage.FromContext(ctx).Member(key).Value()
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestBaggage(t *testing.T) {
+	ctx := SetBaggage(context.Background(), "tenant", "acme")
+	ctx = SetBaggage(ctx, "experiment", "new-cache")
+	if got := Baggage(ctx, "tenant"); got != "acme" {
+		t.Errorf("Baggage(tenant) = %q, want acme", got)
+	}
+	if got := Baggage(ctx, "missing"); got != "" {
+		t.Errorf("Baggage(missing) = %q, want empty", got)
+	}
+	if invalid := SetBaggage(ctx, "", "v"); invalid != ctx {
+		t.Error("SetBaggage with the empty key changed the context")
+	}
+}
+
+func TestBaggagePropagation(t *testing.T) {
+	p := newPropagator(buildOptions(nil))
+
+	carrier := propagation.MapCarrier{}
+	p.Inject(SetBaggage(context.Background(), "tenant", "acme"), carrier)
+	if got := carrier.Get("baggage"); got != "tenant=acme" {
+		t.Errorf("baggage header = %q, want tenant=acme", got)
+	}
+
+	ctx := p.Extract(context.Background(), propagation.MapCarrier{"baggage": "tenant=acme,flag=1"})
+	if got := Baggage(ctx, "flag"); got != "1" {
+		t.Errorf("extracted flag = %q, want 1", got)
+	}
+}
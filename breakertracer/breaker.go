@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+
+// Package breakertracer traces calls through sony/gobreaker circuit breakers.
+package breakertracer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+)
+
+// Breaker is the circuit breaker tracing the calls through it.
+type Breaker struct {
+	cb *gobreaker.CircuitBreaker
+}
+
+// Wrap returns the breaker tracing the calls through cb.
+func Wrap(cb *gobreaker.CircuitBreaker) *Breaker {
+	return &Breaker{cb: cb}
+}
+
+// Execute runs fn through the circuit breaker within the span named name. The
+// span is tagged with the breaker name and state ("circuit_breaker.name",
+// "circuit_breaker.state"), gets the "circuit_breaker.state_change" event if
+// the call changed the state, and is tagged with
+// "circuit_breaker.short_circuited"=true if fn wasn't called because the
+// breaker is open or half-open with too many requests.
+func (b *Breaker) Execute(
+	ctx context.Context, name string, fn func(ctx context.Context) (any, error),
+) (result any, err error) {
+	ctx, span := tracer.StartSpan(ctx, name)
+	defer span.End(&err)
+
+	before := b.cb.State()
+	span.Tag("circuit_breaker.name", b.cb.Name())
+	span.Tag("circuit_breaker.state", before.String())
+
+	result, err = b.cb.Execute(func() (any, error) {
+		return fn(ctx)
+	})
+
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		span.Tag("circuit_breaker.short_circuited", true)
+	}
+	if after := b.cb.State(); after != before {
+		span.AddEvent("circuit_breaker.state_change", trace.WithAttributes(
+			attribute.String("circuit_breaker.from", before.String()),
+			attribute.String("circuit_breaker.to", after.String()),
+		))
+	}
+
+	return result, err
+}
+
+// CircuitBreaker returns the wrapped circuit breaker.
+func (b *Breaker) CircuitBreaker() *gobreaker.CircuitBreaker {
+	return b.cb
+}
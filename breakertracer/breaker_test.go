@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+
+package breakertracer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/cdnnow-pro/go-tracer/breakertracer"
+	"github.com/cdnnow-pro/go-tracer/tracertest"
+)
+
+func TestExecute(t *testing.T) {
+	tracertest.Init(t)
+	b := breakertracer.Wrap(gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        "billing",
+		ReadyToTrip: func(counts gobreaker.Counts) bool { return counts.ConsecutiveFailures >= 1 },
+	}))
+	ctx := context.Background()
+
+	if result, err := b.Execute(ctx, "ok", func(context.Context) (any, error) { return 1, nil }); err != nil || result != 1 {
+		t.Errorf("Execute() = %v, %v, want 1", result, err)
+	}
+	_, _ = b.Execute(ctx, "fail", func(context.Context) (any, error) { return nil, errors.New("boom") })
+	var called bool
+	_, err := b.Execute(ctx, "rejected", func(context.Context) (any, error) {
+		called = true
+		return nil, nil
+	})
+	if called || !errors.Is(err, gobreaker.ErrOpenState) {
+		t.Errorf("open breaker called fn = %v, err = %v", called, err)
+	}
+
+	tracertest.AssertSpan(t, "ok").WithTag("circuit_breaker.name", "billing").WithTag("circuit_breaker.state", "closed").
+		WithStatus(codes.Unset)
+	tracertest.AssertSpan(t, "fail").WithTag("circuit_breaker.state", "closed").WithStatus(codes.Error)
+	tracertest.AssertSpan(t, "rejected").WithTag("circuit_breaker.state", "open").
+		WithTag("circuit_breaker.short_circuited", true).WithStatus(codes.Error)
+
+	for _, s := range tracertest.Spans(t) {
+		var changed bool
+		for _, e := range s.Events() {
+			changed = changed || e.Name == "circuit_breaker.state_change"
+		}
+		if want := s.Name() == "fail"; changed != want {
+			t.Errorf("%s: state change event = %v, want %v", s.Name(), changed, want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CancelPolicy defines how Span.End handles cancellation errors
+// (context.Canceled and gRPC status with the Canceled code).
+type CancelPolicy int
+
+const (
+	// CancelAsEvent adds the "canceled" event and leaves the status unset (default).
+	CancelAsEvent CancelPolicy = iota
+	// CancelAsOk sets the Ok status.
+	CancelAsOk
+	// CancelAsError sets the Error status, as for any other error.
+	CancelAsError
+)
+
+// WithCancelPolicy sets how Span.End handles cancellation errors. It can be
+// overridden per span with Span.SetCancelPolicy.
+func WithCancelPolicy(policy CancelPolicy) Option {
+	return func(opts *Options) {
+		opts.cancelPolicy = policy
+	}
+}
+
+func (s *span) SetCancelPolicy(policy CancelPolicy) {
+	s.cancelPolicy = &policy
+}
+
+// handleCanceled handles the cancellation error according to the policy.
+// Returns false if the error should be handled as any other error.
+func (s *span) handleCanceled(err error) bool {
+	if !isCanceled(err) {
+		return false
+	}
+
+	switch s.effectiveCancelPolicy() {
+	case CancelAsEvent:
+		s.s.AddEvent("canceled", trace.WithTimestamp(time.Now()))
+	case CancelAsOk:
+		s.s.SetStatus(codes.Ok, "")
+	case CancelAsError:
+		return false
+	}
+
+	return true
+}
+
+// effectiveCancelPolicy returns the span cancel policy if set, otherwise the one
+// set by WithCancelPolicy.
+func (s *span) effectiveCancelPolicy() CancelPolicy {
+	if s.cancelPolicy != nil {
+		return *s.cancelPolicy
+	}
+	return s.t.options.cancelPolicy
+}
+
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || status.Code(err) == grpccodes.Canceled
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCancelPolicy(t *testing.T) {
+	policy := func(p CancelPolicy) *CancelPolicy { return &p }
+
+	tests := []struct {
+		name     string
+		global   CancelPolicy
+		override *CancelPolicy
+		err      error
+		status   codes.Code
+		event    bool
+	}{
+		{"default", CancelAsEvent, nil, context.Canceled, codes.Unset, true},
+		{"wrapped", CancelAsEvent, nil, fmt.Errorf("query: %w", context.Canceled), codes.Unset, true},
+		{"grpc status", CancelAsEvent, nil, status.Error(grpccodes.Canceled, "canceled"), codes.Unset, true},
+		{"ok", CancelAsOk, nil, context.Canceled, codes.Ok, false},
+		{"error", CancelAsError, nil, context.Canceled, codes.Error, false},
+		{"span override", CancelAsError, policy(CancelAsOk), context.Canceled, codes.Ok, false},
+		{"other error", CancelAsOk, nil, context.DeadlineExceeded, codes.Error, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t, WithCancelPolicy(tt.global))
+
+			_, span := StartSpan(context.Background(), "span")
+			if tt.override != nil {
+				span.SetCancelPolicy(*tt.override)
+			}
+			span.End(&tt.err)
+
+			s := recorder.Ended()[0]
+			if s.Status().Code != tt.status {
+				t.Errorf("status = %v, want %v", s.Status().Code, tt.status)
+			}
+			var event bool
+			for _, e := range s.Events() {
+				event = event || e.Name == "canceled"
+			}
+			if event != tt.event {
+				t.Errorf("canceled event = %v, want %v", event, tt.event)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// defaultCardinalityWindow is the default window of distinct value counts.
+	defaultCardinalityWindow = time.Minute
+	// defaultCardinalityMaxValues is the default max number of distinct values
+	// of an attribute key within the window.
+	defaultCardinalityMaxValues = 1000
+	// maxCardinalityKeys is the max number of tracked attribute keys, so keys
+	// made of data don't grow the state without bound.
+	maxCardinalityKeys = 1024
+)
+
+// CardinalityLimit configures the adaptive attribute cardinality reduction (see
+// WithAdaptiveCardinality).
+type CardinalityLimit struct {
+	// MaxValues is the max number of distinct values of an attribute key within
+	// the window, 1000 if 0. Keys exceeding it are reduced until the window ends.
+	MaxValues int
+
+	// Window is the period distinct values are counted in, 1 minute if 0.
+	Window time.Duration
+
+	// Buckets is the number of buckets values of reduced keys are spread over:
+	// values become "bucket:<n>". If 0, values become their hash "hash:<hex>",
+	// which keeps equal values equal but bounds only their size.
+	Buckets int
+
+	// Exempt are the keys that are never reduced, e.g. "http.route".
+	Exempt []string
+}
+
+// WithAdaptiveCardinality detects string attribute keys with extreme value
+// cardinality at runtime (e.g. raw URLs, UUIDs) and replaces their values with
+// hashes or buckets before export, protecting the index costs of the backend.
+// The keys reduced in the current window are listed by Tracer.ReducedAttributes.
+func WithAdaptiveCardinality(limit CardinalityLimit) Option {
+	return func(opts *Options) {
+		opts.cardinalityLimit = &limit
+	}
+}
+
+// ReducedAttributes returns the attribute keys reduced by the default tracer
+// (see Init and WithAdaptiveCardinality).
+func ReducedAttributes() []string {
+	return current().ReducedAttributes()
+}
+
+// ReducedAttributes returns the sorted attribute keys whose values are reduced
+// because of high cardinality.
+func (t *Tracer) ReducedAttributes() []string {
+	if t.cardinality == nil {
+		return nil
+	}
+	return t.cardinality.reducedKeys()
+}
+
+// cardinalityGuard counts distinct values of attribute keys and reduces the
+// values of keys over the limit.
+type cardinalityGuard struct {
+	limit  CardinalityLimit
+	exempt map[attribute.Key]bool
+
+	mu          sync.Mutex
+	windowStart time.Time
+	values      map[attribute.Key]map[uint64]struct{} // value hashes in the window
+	reduced     map[attribute.Key]bool
+}
+
+func newCardinalityGuard(limit CardinalityLimit) *cardinalityGuard {
+	if limit.Window <= 0 {
+		limit.Window = defaultCardinalityWindow
+	}
+	if limit.MaxValues <= 0 {
+		limit.MaxValues = defaultCardinalityMaxValues
+	}
+	g := &cardinalityGuard{
+		limit:   limit,
+		exempt:  make(map[attribute.Key]bool, len(limit.Exempt)),
+		values:  make(map[attribute.Key]map[uint64]struct{}),
+		reduced: make(map[attribute.Key]bool),
+	}
+	for _, key := range limit.Exempt {
+		g.exempt[attribute.Key(key)] = true
+	}
+	return g
+}
+
+func (g *cardinalityGuard) reducedKeys() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	keys := make([]string, 0, len(g.reduced))
+	for key := range g.reduced {
+		keys = append(keys, string(key))
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// reduce returns attrs with the values of reduced keys replaced, and if any
+// were replaced.
+func (g *cardinalityGuard) reduce(attrs []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if now := time.Now(); now.Sub(g.windowStart) > g.limit.Window {
+		g.windowStart = now
+		clear(g.values)
+		clear(g.reduced)
+	}
+
+	replaced := false
+	for i, kv := range attrs {
+		if kv.Value.Type() != attribute.STRING || g.exempt[kv.Key] {
+			continue
+		}
+		hash := valueHash(kv.Value.AsString())
+		if !g.reduced[kv.Key] && !g.observe(kv.Key, hash) {
+			continue
+		}
+
+		if !replaced {
+			attrs = slices.Clone(attrs)
+			replaced = true
+		}
+		if g.limit.Buckets > 0 {
+			attrs[i].Value = attribute.StringValue(fmt.Sprintf("bucket:%d", hash%uint64(g.limit.Buckets)))
+		} else {
+			attrs[i].Value = attribute.StringValue(fmt.Sprintf("hash:%016x", hash))
+		}
+	}
+	return attrs, replaced
+}
+
+// observe counts the value hash of the key. Returns true if the key exceeded
+// the limit and was reduced.
+func (g *cardinalityGuard) observe(key attribute.Key, hash uint64) bool {
+	values, ok := g.values[key]
+	if !ok {
+		if len(g.values) >= maxCardinalityKeys {
+			return false
+		}
+		values = make(map[uint64]struct{})
+		g.values[key] = values
+	}
+
+	values[hash] = struct{}{}
+	if len(values) <= g.limit.MaxValues {
+		return false
+	}
+	g.reduced[key] = true
+	delete(g.values, key)
+	return true
+}
+
+func valueHash(value string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(value))
+	return h.Sum64()
+}
+
+// cardinalityProcessor reduces high cardinality attributes before passing spans
+// to the next processor.
+type cardinalityProcessor struct {
+	next  tracesdk.SpanProcessor
+	guard *cardinalityGuard
+}
+
+var _ tracesdk.SpanProcessor = cardinalityProcessor{}
+
+func (p cardinalityProcessor) OnStart(parent context.Context, s tracesdk.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p cardinalityProcessor) OnEnd(s tracesdk.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() {
+		if attrs, replaced := p.guard.reduce(s.Attributes()); replaced {
+			s = reducedSpan{ReadOnlySpan: s, attrs: attrs}
+		}
+	}
+	p.next.OnEnd(s)
+}
+
+func (p cardinalityProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p cardinalityProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// reducedSpan is the span with high cardinality attribute values reduced.
+type reducedSpan struct {
+	tracesdk.ReadOnlySpan
+
+	attrs []attribute.KeyValue
+}
+
+func (s reducedSpan) Attributes() []attribute.KeyValue {
+	return s.attrs
+}
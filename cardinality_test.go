@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestCardinalityGuard(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   CardinalityLimit
+		values  int
+		reduced bool
+	}{
+		{"default limit", CardinalityLimit{}, 10, false},
+		{"default limit exceeded", CardinalityLimit{}, defaultCardinalityMaxValues + 1, true},
+		{"negative limit", CardinalityLimit{MaxValues: -1}, 10, false},
+		{"within limit", CardinalityLimit{MaxValues: 3}, 3, false},
+		{"limit exceeded", CardinalityLimit{MaxValues: 3}, 4, true},
+		{"exempt", CardinalityLimit{MaxValues: 3, Exempt: []string{"k"}}, 4, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newCardinalityGuard(tt.limit)
+			for i := range tt.values {
+				g.reduce([]attribute.KeyValue{attribute.String("k", strconv.Itoa(i))})
+			}
+
+			_, replaced := g.reduce([]attribute.KeyValue{attribute.String("k", "0")})
+			if replaced != tt.reduced {
+				t.Errorf("replaced = %v, want %v", replaced, tt.reduced)
+			}
+			if reduced := len(g.reducedKeys()) == 1; reduced != tt.reduced {
+				t.Errorf("reduced keys = %v, want reduced %v", g.reducedKeys(), tt.reduced)
+			}
+		})
+	}
+}
+
+func TestCardinalityGuardWindow(t *testing.T) {
+	g := newCardinalityGuard(CardinalityLimit{MaxValues: 1, Window: time.Hour})
+	g.reduce([]attribute.KeyValue{attribute.String("k", "a")})
+	g.reduce([]attribute.KeyValue{attribute.String("k", "b")})
+	if keys := g.reducedKeys(); len(keys) != 1 {
+		t.Fatalf("reduced keys = %v, want [k]", keys)
+	}
+
+	g.windowStart = g.windowStart.Add(-2 * time.Hour)
+	if _, replaced := g.reduce([]attribute.KeyValue{attribute.String("k", "a")}); replaced {
+		t.Error("the key is reduced after the window ended")
+	}
+	if keys := g.reducedKeys(); len(keys) != 0 {
+		t.Errorf("reduced keys = %v, want none", keys)
+	}
+}
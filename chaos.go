@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// FaultTarget is an instrumented helper faults can be injected into.
+type FaultTarget string
+
+const (
+	FaultHTTPServer FaultTarget = "http.server"
+	FaultHTTPClient FaultTarget = "http.client"
+	FaultGRPCServer FaultTarget = "grpc.server"
+	FaultGRPCClient FaultTarget = "grpc.client"
+	FaultSQL        FaultTarget = "sql.client"
+)
+
+// Fault is the artificial latency and/or error injected into instrumented
+// helpers.
+type Fault struct {
+	// Targets are the helpers the fault is injected into, all if empty.
+	Targets []FaultTarget
+
+	// Latency is the delay before the operation.
+	Latency time.Duration
+
+	// Err is the error the operation fails with, if not nil.
+	Err error
+}
+
+// WithFaultInjection enables fault injection coordinated through the trace
+// context: when the baggage has the member baggageKey, the fault named by its
+// value is injected into the instrumented helpers (e.g. "chaos=slow-upstream"
+// selects faults["slow-upstream"]). Spans of affected operations are tagged with
+// the fault name ("chaos.fault") and the injected latency ("chaos.latency_ms").
+// The flag is propagated to downstream services with the baggage.
+//
+// Flags of inbound requests (Middleware, server interceptors) are honored only
+// from callers trusted by the policy explicitly set with WithInboundTrustPolicy,
+// otherwise they are removed from the baggage, so external clients can't inject
+// faults.
+func WithFaultInjection(baggageKey string, faults map[string]Fault) Option {
+	return func(opts *Options) {
+		opts.faultInjection = &faultInjection{baggageKey: baggageKey, faults: faults}
+	}
+}
+
+type faultInjection struct {
+	baggageKey string
+	faults     map[string]Fault
+}
+
+// stripInboundFault removes the fault flag from the baggage extracted from the
+// inbound request into ctx, unless trusted and the caller is trusted by the
+// explicit inbound trust policy.
+func stripInboundFault(ctx context.Context, in Inbound, trusted bool) context.Context {
+	options := current().options
+	fi := options.faultInjection
+	if fi == nil || (trusted && options.trustPolicy != nil && options.trustPolicy(in)) {
+		return ctx
+	}
+
+	bag := baggage.FromContext(ctx)
+	if bag.Member(fi.baggageKey).Key() == "" {
+		return ctx
+	}
+	return baggage.ContextWithBaggage(ctx, bag.DeleteMember(fi.baggageKey))
+}
+
+// InjectFault injects the fault selected by the ctx baggage (see
+// WithFaultInjection) into the operation of the target traced by the span of ctx,
+// for instrumentation outside this package. Returns the fault error, or the ctx
+// error if ctx is done during the injected latency.
+func InjectFault(ctx context.Context, target FaultTarget) error {
+	return injectFault(ctx, target, SpanFromContext(ctx))
+}
+
+// injectFault injects the fault selected by the ctx baggage into the operation
+// of the target traced by span. Returns the fault error, or the ctx error if ctx
+// is done during the injected latency.
+func injectFault(ctx context.Context, target FaultTarget, span *span) error {
+	fi := span.t.options.faultInjection
+	if fi == nil {
+		return nil
+	}
+
+	name := baggage.FromContext(ctx).Member(fi.baggageKey).Value()
+	if name == "" {
+		return nil
+	}
+	fault, ok := fi.faults[name]
+	if !ok || (len(fault.Targets) > 0 && !slices.Contains(fault.Targets, target)) {
+		return nil
+	}
+
+	span.s.SetAttributes(
+		attribute.String("chaos.fault", name),
+		attribute.Int64("chaos.latency_ms", fault.Latency.Milliseconds()),
+	)
+
+	if fault.Latency > 0 {
+		timer := time.NewTimer(fault.Latency)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return fault.Err
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+func TestStripInboundFault(t *testing.T) {
+	member, _ := baggage.NewMember("chaos", "slow")
+	bag, _ := baggage.New(member)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+	internal := netip.MustParsePrefix("10.0.0.0/8")
+
+	tests := []struct {
+		name    string
+		enabled bool
+		opts    []Option
+		addr    string
+		trusted bool
+		want    string
+	}{
+		{"disabled", false, nil, "1.2.3.4:1", true, "slow"},
+		{"default policy", true, nil, "10.0.0.1:1", true, ""},
+		{"trusted caller", true, []Option{WithInboundTrustPolicy(TrustCIDRs(internal))}, "10.0.0.1:1", true, "slow"},
+		{"untrusted caller", true, []Option{WithInboundTrustPolicy(TrustCIDRs(internal))}, "1.2.3.4:1", true, ""},
+		{"untrusted remote", true, []Option{WithInboundTrustPolicy(TrustAll())}, "10.0.0.1:1", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{Noop()}, tt.opts...)
+			if tt.enabled {
+				opts = append(opts, WithFaultInjection("chaos", map[string]Fault{"slow": {}}))
+			}
+			tr, err := New(context.Background(), "test", "1", opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defaultTracer = tr
+			t.Cleanup(func() { defaultTracer = nil })
+
+			got := baggage.FromContext(stripInboundFault(ctx, Inbound{RemoteAddr: tt.addr}, tt.trusted))
+			if value := got.Member("chaos").Value(); value != tt.want {
+				t.Errorf("chaos baggage = %q, want %q", value, tt.want)
+			}
+		})
+	}
+}
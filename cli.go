@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+)
+
+// WithCLIMode tunes the tracer for short-lived commands: spans are exported
+// immediately without batching, and Init starts the root span named after the
+// application that becomes the parent of all spans started without a parent.
+// The root span is ended by the closer returned from Init, which flushes all
+// spans before returning.
+//
+// Use SetExitCode to tag the root span with the command exit code.
+func WithCLIMode() Option {
+	return func(opts *Options) {
+		opts.cliMode = true
+	}
+}
+
+// SetExitCode tags the root span of the command run in the CLI mode with the exit
+// code. Non-zero code sets the Error status. It does nothing outside the CLI mode.
+func SetExitCode(code int) {
+	current().SetExitCode(code)
+}
+
+// SetExitCode is the Tracer version of the package-level SetExitCode.
+func (t *Tracer) SetExitCode(code int) {
+	root := t.cliRoot.Load()
+	if root == nil {
+		return
+	}
+
+	root.s.SetAttributes(semconv.ProcessExitCode(code))
+	if code != 0 {
+		root.s.SetStatus(codes.Error, fmt.Sprintf("exit code %d", code))
+	}
+}
+
+func (t *Tracer) startCLIRootSpan(name string) {
+	_, root := t.StartSpan(context.Background(), name)
+	t.cliRoot.Store(root)
+}
+
+func (t *Tracer) endCLIRootSpan() {
+	if root := t.cliRoot.Swap(nil); root != nil {
+		root.End()
+	}
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: MIT
+
+package tracer_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+)
+
+func TestCLIRootSpanConcurrentShutdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		exitCode int
+	}{
+		{"success", 0},
+		{"failure", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tr, err := tracer.New(context.Background(), "cmd", "1.0", tracer.WithCLIMode(), tracer.WithExporter(exporter))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var wg sync.WaitGroup
+			for range 4 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, span := tr.StartSpan(context.Background(), "step")
+					span.End()
+					tr.SetExitCode(tt.exitCode)
+				}()
+			}
+			// Spans ending during shutdown are reported as abandoned.
+			_ = tr.Shutdown(context.Background())
+			wg.Wait()
+			tr.SetExitCode(tt.exitCode) // no-op after shutdown
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// WithClockOffset corrects span and event timestamps of hosts with known clock
+// drift by the offset returned by offset (e.g. fed by the NTP/chrony status),
+// added before export. offset is called once per exported batch.
+func WithClockOffset(offset func() time.Duration) Option {
+	return func(opts *Options) {
+		opts.clockOffset = offset
+	}
+}
+
+// withClockOffset returns exporter correcting timestamps by offset, exporter
+// itself if offset is nil.
+func withClockOffset(exporter tracesdk.SpanExporter, offset func() time.Duration) tracesdk.SpanExporter {
+	if offset == nil {
+		return exporter
+	}
+	return offsetExporter{SpanExporter: exporter, offset: offset}
+}
+
+// offsetExporter corrects timestamps of exported spans.
+type offsetExporter struct {
+	tracesdk.SpanExporter
+
+	offset func() time.Duration
+}
+
+func (e offsetExporter) ExportSpans(ctx context.Context, spans []tracesdk.ReadOnlySpan) error {
+	if offset := e.offset(); offset != 0 {
+		shifted := make([]tracesdk.ReadOnlySpan, len(spans))
+		for i, s := range spans {
+			shifted[i] = shiftedSpan{ReadOnlySpan: s, offset: offset}
+		}
+		spans = shifted
+	}
+	return e.SpanExporter.ExportSpans(ctx, spans) //nolint:wrapcheck
+}
+
+// shiftedSpan is the span with timestamps shifted by offset.
+type shiftedSpan struct {
+	tracesdk.ReadOnlySpan
+
+	offset time.Duration
+}
+
+func (s shiftedSpan) StartTime() time.Time {
+	return s.ReadOnlySpan.StartTime().Add(s.offset)
+}
+
+func (s shiftedSpan) EndTime() time.Time {
+	return s.ReadOnlySpan.EndTime().Add(s.offset)
+}
+
+func (s shiftedSpan) Events() []tracesdk.Event {
+	events := slices.Clone(s.ReadOnlySpan.Events())
+	for i := range events {
+		events[i].Time = events[i].Time.Add(s.offset)
+	}
+	return events
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestClockOffset(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	stub := tracetest.SpanStub{
+		Name:      "op",
+		StartTime: start,
+		EndTime:   start.Add(time.Second),
+		Events:    []tracesdk.Event{{Name: "retry", Time: start.Add(time.Millisecond)}},
+	}
+
+	for _, offset := range []time.Duration{0, -2 * time.Second} {
+		t.Run(offset.String(), func(t *testing.T) {
+			span := stub.Snapshot()
+			var exported tracesdk.ReadOnlySpan
+			e := withClockOffset(exportFunc(func(_ context.Context, spans []tracesdk.ReadOnlySpan) error {
+				exported = spans[0]
+				return nil
+			}), func() time.Duration { return offset })
+			if err := e.ExportSpans(context.Background(), []tracesdk.ReadOnlySpan{span}); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := exported.StartTime(), start.Add(offset); !got.Equal(want) {
+				t.Errorf("start = %v, want %v", got, want)
+			}
+			if got, want := exported.EndTime(), start.Add(time.Second+offset); !got.Equal(want) {
+				t.Errorf("end = %v, want %v", got, want)
+			}
+			if got, want := exported.Events()[0].Time, start.Add(time.Millisecond+offset); !got.Equal(want) {
+				t.Errorf("event time = %v, want %v", got, want)
+			}
+			if got := span.Events()[0].Time; !got.Equal(start.Add(time.Millisecond)) {
+				t.Errorf("original event time changed to %v", got)
+			}
+		})
+	}
+
+	if e := withClockOffset(exportFunc(nil), nil); e == nil {
+		t.Error("nil offset doesn't return the exporter")
+	}
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanContextCodecVersion is the version of the binary span context format.
+//
+// Version 1 layout:
+//
+//	version    1 byte
+//	trace ID   16 bytes
+//	span ID    8 bytes
+//	flags      1 byte
+//	tracestate uvarint length + W3C tracestate string
+//
+// Newer versions may only append fields, so the decoder reads the known prefix
+// of a payload with a higher version and ignores the rest.
+const spanContextCodecVersion byte = 1
+
+const spanContextV1MinSize = 1 + 16 + 8 + 1 + 1
+
+var ErrInvalidSpanContext = errors.New("invalid span context")
+
+// MarshalSpanContext encodes the span context from ctx into the compact binary
+// form suitable for storing in database columns and job payloads.
+func MarshalSpanContext(ctx context.Context) ([]byte, error) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil, ErrInvalidSpanContext
+	}
+
+	traceID := sc.TraceID()
+	spanID := sc.SpanID()
+	state := sc.TraceState().String()
+
+	buf := make([]byte, 0, spanContextV1MinSize+len(state))
+	buf = append(buf, spanContextCodecVersion)
+	buf = append(buf, traceID[:]...)
+	buf = append(buf, spanID[:]...)
+	buf = append(buf, byte(sc.TraceFlags()))
+	buf = binary.AppendUvarint(buf, uint64(len(state)))
+	buf = append(buf, state...)
+
+	return buf, nil
+}
+
+// UnmarshalSpanContext decodes the span context encoded by MarshalSpanContext.
+// The returned span context is marked as remote.
+func UnmarshalSpanContext(data []byte) (trace.SpanContext, error) {
+	if len(data) < spanContextV1MinSize {
+		return trace.SpanContext{}, fmt.Errorf("%w: payload too short", ErrInvalidSpanContext)
+	}
+	if data[0] < 1 {
+		return trace.SpanContext{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidSpanContext, data[0])
+	}
+
+	var (
+		traceID trace.TraceID
+		spanID  trace.SpanID
+	)
+	data = data[1:]
+	data = data[copy(traceID[:], data):]
+	data = data[copy(spanID[:], data):]
+	flags := trace.TraceFlags(data[0])
+	data = data[1:]
+
+	stateLen, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < stateLen {
+		return trace.SpanContext{}, fmt.Errorf("%w: malformed tracestate", ErrInvalidSpanContext)
+	}
+	state, err := trace.ParseTraceState(string(data[n : n+int(stateLen)])) //nolint:gosec
+	if err != nil {
+		return trace.SpanContext{}, fmt.Errorf("%w: %w", ErrInvalidSpanContext, err)
+	}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: flags,
+		TraceState: state,
+		Remote:     true,
+	})
+	if !sc.IsValid() {
+		return trace.SpanContext{}, ErrInvalidSpanContext
+	}
+
+	return sc, nil
+}
+
+// EncodeSpanContext is MarshalSpanContext with the result encoded as unpadded
+// URL-safe base64, for text columns and payloads.
+func EncodeSpanContext(ctx context.Context) (string, error) {
+	data, err := MarshalSpanContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeSpanContext decodes the span context encoded by EncodeSpanContext.
+func DecodeSpanContext(s string) (trace.SpanContext, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return trace.SpanContext{}, fmt.Errorf("%w: %w", ErrInvalidSpanContext, err)
+	}
+	return UnmarshalSpanContext(data)
+}
+
+// ContextWithRemoteSpanContext returns ctx carrying the remote span context
+// encoded by MarshalSpanContext, e.g. taken from a job payload, so spans started
+// with it continue the producer's trace.
+func ContextWithRemoteSpanContext(ctx context.Context, data []byte) (context.Context, error) {
+	sc, err := UnmarshalSpanContext(data)
+	if err != nil {
+		return ctx, err
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc), nil
+}
@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func testSpanContext(tb testing.TB, state string) trace.SpanContext {
+	tb.Helper()
+
+	ts, err := trace.ParseTraceState(state)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+		TraceState: ts,
+	})
+}
+
+func TestSpanContextCodec(t *testing.T) {
+	for _, state := range []string{"", "vendor=value,other=1"} {
+		t.Run(state, func(t *testing.T) {
+			sc := testSpanContext(t, state)
+			ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+			data, err := MarshalSpanContext(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := UnmarshalSpanContext(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(sc.WithRemote(true)) {
+				t.Errorf("UnmarshalSpanContext() = %v, want %v", got, sc)
+			}
+
+			s, err := EncodeSpanContext(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err = DecodeSpanContext(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(sc.WithRemote(true)) {
+				t.Errorf("DecodeSpanContext() = %v, want %v", got, sc)
+			}
+		})
+	}
+}
+
+func TestUnmarshalSpanContextErrors(t *testing.T) {
+	valid, err := MarshalSpanContext(trace.ContextWithSpanContext(context.Background(), testSpanContext(t, "k=v")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	withVersion := func(version byte, data []byte) []byte {
+		return append([]byte{version}, data[1:]...)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{"valid", valid, false},
+		{"newer version with appended fields", append(withVersion(2, valid), 0xff, 0xff), false},
+		{"short", valid[:10], true},
+		{"version 0", withVersion(0, valid), true},
+		{"tracestate length overflow", append(valid[:26:26], 0x7f), true},
+		{"invalid tracestate", append(valid[:26:26], 3, '!', '!', '!'), true},
+		{"zero IDs", make([]byte, spanContextV1MinSize), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalSpanContext(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSpanContext) {
+				t.Errorf("err = %v, want ErrInvalidSpanContext", err)
+			}
+		})
+	}
+
+	if _, err := MarshalSpanContext(context.Background()); !errors.Is(err, ErrInvalidSpanContext) {
+		t.Errorf("MarshalSpanContext() without span err = %v, want ErrInvalidSpanContext", err)
+	}
+	if _, err := DecodeSpanContext("not base64!"); !errors.Is(err, ErrInvalidSpanContext) {
+		t.Errorf("DecodeSpanContext() err = %v, want ErrInvalidSpanContext", err)
+	}
+}
+
+func TestContextWithRemoteSpanContext(t *testing.T) {
+	recorder := initTestTracer(t)
+	sc := testSpanContext(t, "")
+	data, err := MarshalSpanContext(trace.ContextWithSpanContext(context.Background(), sc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, err := ContextWithRemoteSpanContext(context.Background(), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := StartSpan(ctx, "job")
+	span.End()
+	if s := recorder.Ended()[0]; s.Parent().SpanID() != sc.SpanID() || !s.Parent().IsRemote() {
+		t.Errorf("parent = %v, want remote %v", s.Parent(), sc)
+	}
+
+	if ctx, err := ContextWithRemoteSpanContext(context.Background(), data[:10]); !errors.Is(err, ErrInvalidSpanContext) ||
+		trace.SpanContextFromContext(ctx).IsValid() {
+		t.Errorf("invalid data err = %v, want ErrInvalidSpanContext and ctx unchanged", err)
+	}
+}
+
+func FuzzUnmarshalSpanContext(f *testing.F) {
+	for _, state := range []string{"", "k=v"} {
+		data, err := MarshalSpanContext(trace.ContextWithSpanContext(context.Background(), testSpanContext(f, state)))
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		sc, err := UnmarshalSpanContext(data)
+		if err != nil {
+			return
+		}
+		again, err := MarshalSpanContext(trace.ContextWithSpanContext(context.Background(), sc))
+		if err != nil {
+			t.Fatalf("decoded span context %v doesn't encode: %v", sc, err)
+		}
+		if got, err := UnmarshalSpanContext(again); err != nil || !got.Equal(sc) {
+			t.Errorf("round trip = %v, %v, want %v", got, err, sc)
+		}
+	})
+}
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxCompressionGroups limits the number of pending groups of the compression
+// processor. All groups are flushed when the limit is reached.
+const maxCompressionGroups = 4096
+
+// WithSpanCompression collapses runs of identical sibling spans (same parent,
+// name, kind, status and attributes) not longer than maxDuration into a single
+// span tagged with the number of collapsed spans ("span.collapsed.count") and
+// their total duration ("span.collapsed.duration_ms"). E.g. 500 cache GETs in a
+// loop are exported as one span.
+//
+// Spans with children, events, links, the Error status or a remote parent (e.g.
+// server spans, whose parent never ends locally) are never collapsed.
+func WithSpanCompression(maxDuration time.Duration) Option {
+	return func(opts *Options) {
+		opts.compressionMaxDuration = maxDuration
+	}
+}
+
+type compressionKey struct {
+	parent trace.SpanID
+	name   string
+	kind   trace.SpanKind
+	status codes.Code
+	attrs  attribute.Distinct
+}
+
+type compressionGroup struct {
+	first    tracesdk.ReadOnlySpan
+	count    int64
+	duration time.Duration
+	endTime  time.Time
+}
+
+// compressionProcessor buffers short spans grouped by compressionKey and passes
+// a group to the next processor as a single span once the parent span ends.
+type compressionProcessor struct {
+	next        tracesdk.SpanProcessor
+	maxDuration time.Duration
+
+	mu     sync.Mutex
+	groups map[compressionKey]*compressionGroup
+}
+
+var _ tracesdk.SpanProcessor = (*compressionProcessor)(nil)
+
+func newCompressionProcessor(next tracesdk.SpanProcessor, maxDuration time.Duration) *compressionProcessor {
+	return &compressionProcessor{
+		next:        next,
+		maxDuration: maxDuration,
+		groups:      make(map[compressionKey]*compressionGroup),
+	}
+}
+
+func (p *compressionProcessor) OnStart(parent context.Context, s tracesdk.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p *compressionProcessor) OnEnd(s tracesdk.ReadOnlySpan) {
+	p.mu.Lock()
+	ready := p.takeChildren(s.SpanContext().SpanID())
+
+	if p.compressible(s) {
+		attrs := attribute.NewSet(s.Attributes()...)
+		key := compressionKey{
+			parent: s.Parent().SpanID(),
+			name:   s.Name(),
+			kind:   s.SpanKind(),
+			status: s.Status().Code,
+			attrs:  attrs.Equivalent(),
+		}
+		if group, ok := p.groups[key]; ok {
+			group.add(s)
+			p.mu.Unlock()
+			p.forward(ready)
+			return
+		}
+		if len(p.groups) >= maxCompressionGroups {
+			ready = append(ready, p.takeAll()...)
+		}
+		p.groups[key] = &compressionGroup{first: s, count: 1, duration: duration(s), endTime: s.EndTime()}
+		p.mu.Unlock()
+		p.forward(ready)
+		return
+	}
+	p.mu.Unlock()
+
+	p.forward(ready)
+	p.next.OnEnd(s)
+}
+
+func (p *compressionProcessor) Shutdown(ctx context.Context) error {
+	p.flush()
+	return p.next.Shutdown(ctx)
+}
+
+func (p *compressionProcessor) ForceFlush(ctx context.Context) error {
+	p.flush()
+	return p.next.ForceFlush(ctx)
+}
+
+func (p *compressionProcessor) compressible(s tracesdk.ReadOnlySpan) bool {
+	return s.Parent().IsValid() &&
+		!s.Parent().IsRemote() &&
+		duration(s) <= p.maxDuration &&
+		s.ChildSpanCount() == 0 &&
+		len(s.Events()) == 0 &&
+		len(s.Links()) == 0 &&
+		s.Status().Code != codes.Error
+}
+
+func (p *compressionProcessor) flush() {
+	p.mu.Lock()
+	ready := p.takeAll()
+	p.mu.Unlock()
+
+	p.forward(ready)
+}
+
+// takeChildren removes and returns the groups of children of the parent span.
+func (p *compressionProcessor) takeChildren(parent trace.SpanID) []*compressionGroup {
+	var groups []*compressionGroup
+	for key, group := range p.groups {
+		if key.parent == parent {
+			groups = append(groups, group)
+			delete(p.groups, key)
+		}
+	}
+	return groups
+}
+
+func (p *compressionProcessor) takeAll() []*compressionGroup {
+	groups := make([]*compressionGroup, 0, len(p.groups))
+	for _, group := range p.groups {
+		groups = append(groups, group)
+	}
+	clear(p.groups)
+	return groups
+}
+
+func (p *compressionProcessor) forward(groups []*compressionGroup) {
+	for _, group := range groups {
+		p.next.OnEnd(group.span())
+	}
+}
+
+func (g *compressionGroup) add(s tracesdk.ReadOnlySpan) {
+	g.count++
+	g.duration += duration(s)
+	if s.EndTime().After(g.endTime) {
+		g.endTime = s.EndTime()
+	}
+}
+
+// span returns the span representing the group.
+func (g *compressionGroup) span() tracesdk.ReadOnlySpan {
+	if g.count == 1 {
+		return g.first
+	}
+
+	attrs := slices.Concat(g.first.Attributes(), []attribute.KeyValue{
+		attribute.Int64("span.collapsed.count", g.count),
+		attribute.Int64("span.collapsed.duration_ms", g.duration.Milliseconds()),
+	})
+	return collapsedSpan{ReadOnlySpan: g.first, endTime: g.endTime, attrs: attrs, count: g.count}
+}
+
+// collapsedSpan is the first span of a group with the group end time and
+// attributes.
+type collapsedSpan struct {
+	tracesdk.ReadOnlySpan
+
+	endTime time.Time
+	attrs   []attribute.KeyValue
+	count   int64
+}
+
+func (s collapsedSpan) EndTime() time.Time {
+	return s.endTime
+}
+
+func (s collapsedSpan) Attributes() []attribute.KeyValue {
+	return s.attrs
+}
+
+func duration(s tracesdk.ReadOnlySpan) time.Duration {
+	return s.EndTime().Sub(s.StartTime())
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestCompressionProcessor(t *testing.T) {
+	local := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{1}})
+	remote := local.WithRemote(true)
+	start := time.Unix(0, 0)
+	short := func(name string, parent trace.SpanContext) tracetest.SpanStub {
+		return tracetest.SpanStub{
+			Name: name, Parent: parent, StartTime: start, EndTime: start.Add(time.Millisecond),
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2}}),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		spans   []tracetest.SpanStub
+		pending int // spans held until the parent ends
+		ended   int // spans passed on without the parent ending
+	}{
+		{"identical siblings", []tracetest.SpanStub{short("get", local), short("get", local)}, 1, 0},
+		{"different names", []tracetest.SpanStub{short("get", local), short("set", local)}, 2, 0},
+		{"remote parent", []tracetest.SpanStub{short("server", remote), short("server", remote)}, 0, 2},
+		{"root", []tracetest.SpanStub{short("root", trace.SpanContext{})}, 0, 1},
+		{"long", []tracetest.SpanStub{func() tracetest.SpanStub {
+			s := short("get", local)
+			s.EndTime = start.Add(time.Second)
+			return s
+		}()}, 0, 1},
+		{"error", []tracetest.SpanStub{func() tracetest.SpanStub {
+			s := short("get", local)
+			s.Status = tracesdk.Status{Code: codes.Error}
+			return s
+		}()}, 0, 1},
+		{"attributes differ", []tracetest.SpanStub{
+			func() tracetest.SpanStub {
+				s := short("get", local)
+				s.Attributes = []attribute.KeyValue{attribute.String("k", "a")}
+				return s
+			}(),
+			short("get", local),
+		}, 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			p := newCompressionProcessor(recorder, 10*time.Millisecond)
+			for _, s := range tracetest.SpanStubs(tt.spans).Snapshots() {
+				p.OnEnd(s)
+			}
+
+			if got := len(recorder.Ended()); got != tt.ended {
+				t.Errorf("passed on %d spans, want %d", got, tt.ended)
+			}
+			if got := len(p.groups); got != tt.pending {
+				t.Errorf("held %d groups, want %d", got, tt.pending)
+			}
+			if err := p.Shutdown(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if got := len(recorder.Ended()); got != tt.ended+tt.pending {
+				t.Errorf("passed on %d spans after shutdown, want %d", got, tt.ended+tt.pending)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// redacted replaces secrets in the config snapshot.
+const redacted = "REDACTED"
+
+// Config is the effective configuration of the tracer, with secrets (keys,
+// header values) redacted.
+type Config struct {
+	Noop     bool   `json:"noop"`
+	Exporter string `json:"exporter"` // "grpc", "http", "stdout", "file" or "custom"
+	Endpoint string `json:"endpoint"`
+	TLS      bool   `json:"tls"`
+
+	// Headers are the collector headers, with values redacted.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	Sampler   string        `json:"sampler"`
+	Processor string        `json:"processor"`          // "batch", "priority" or "simple"
+	Pipeline  []string      `json:"pipeline,omitempty"` // processor kinds: "enrich", "filter", "redact" or "custom"
+	Batch     BatchConfig   `json:"batch"`
+	Probe     time.Duration `json:"probe,omitempty"`
+
+	// Propagators are the header fields the propagator reads and writes.
+	Propagators []string `json:"propagators"`
+
+	Resource            map[string]string `json:"resource"`
+	AdditionalExporters int               `json:"additional_exporters"`
+	Logs                bool              `json:"logs"`
+	Metrics             bool              `json:"metrics"`
+
+	AuditTrailEndpoint string            `json:"audit_trail_endpoint,omitempty"`
+	AuditTrailHeaders  map[string]string `json:"audit_trail_headers,omitempty"`
+	EncryptionKeyID    string            `json:"encryption_key_id,omitempty"`
+}
+
+// BatchConfig is the effective batch span processor config.
+type BatchConfig struct {
+	BatchTimeout       time.Duration `json:"batch_timeout"`
+	ExportTimeout      time.Duration `json:"export_timeout"`
+	MaxQueueSize       int           `json:"max_queue_size"`
+	MaxExportBatchSize int           `json:"max_export_batch_size"`
+}
+
+// ConfigSnapshot returns the effective configuration of the default tracer (see
+// Init).
+func ConfigSnapshot() Config {
+	return current().ConfigSnapshot()
+}
+
+// ConfigSnapshot returns the effective configuration of the tracer.
+func (t *Tracer) ConfigSnapshot() Config {
+	o := t.options
+	snapshot := Config{
+		Noop:                o.noop,
+		Exporter:            "grpc",
+		Endpoint:            o.GetGrpcTarget(),
+		TLS:                 o.tlsConfig != nil || o.tlsFiles != nil,
+		Sampler:             newSampler(o).Description(),
+		Processor:           "batch",
+		Batch:               batchSnapshot(o),
+		Propagators:         t.propagator.Fields(),
+		Resource:            make(map[string]string, len(o.resourceAttributes)),
+		AdditionalExporters: len(o.additionalExporters),
+		Logs:                o.logs != nil,
+		Metrics:             o.metrics,
+		Probe:               o.collectorProbe,
+		Pipeline:            o.pipeline.kinds(),
+	}
+
+	switch {
+	case o.localExporter != nil && o.localExporter.exporter != nil:
+		snapshot.Exporter, snapshot.Endpoint = "custom", ""
+	case o.localExporter != nil && o.localExporter.path == "":
+		snapshot.Exporter, snapshot.Endpoint = "stdout", ""
+	case o.localExporter != nil:
+		snapshot.Exporter, snapshot.Endpoint = "file", o.localExporter.path
+	case o.httpExporter:
+		snapshot.Exporter, snapshot.Endpoint = "http", o.GetHTTPEndpoint()+o.urlPath
+	}
+
+	switch {
+	case o.cliMode:
+		snapshot.Processor = "simple"
+	case o.criticalLane:
+		snapshot.Processor = "priority"
+	}
+
+	for key := range o.collectorHeaders {
+		if snapshot.Headers == nil {
+			snapshot.Headers = make(map[string]string, len(o.collectorHeaders))
+		}
+		snapshot.Headers[key] = redacted
+	}
+	for _, kv := range o.resourceAttributes {
+		snapshot.Resource[string(kv.Key)] = kv.Value.Emit()
+	}
+	if o.auditTrail != nil {
+		snapshot.AuditTrailEndpoint = o.auditTrail.endpoint
+		snapshot.AuditTrailHeaders = make(map[string]string, len(o.auditTrail.headers))
+		for key := range o.auditTrail.headers {
+			snapshot.AuditTrailHeaders[key] = redacted
+		}
+	}
+	if o.encryption != nil {
+		snapshot.EncryptionKeyID = strings.TrimSuffix(strings.TrimPrefix(o.encryption.prefix, encryptedPrefix), ":")
+	}
+
+	return snapshot
+}
+
+// batchConfig returns the batch span processor config with defaults applied.
+func batchSnapshot(o Options) BatchConfig {
+	snapshot := BatchConfig{
+		BatchTimeout:       tracesdk.DefaultScheduleDelay * time.Millisecond,
+		ExportTimeout:      tracesdk.DefaultExportTimeout * time.Millisecond,
+		MaxQueueSize:       tracesdk.DefaultMaxQueueSize,
+		MaxExportBatchSize: tracesdk.DefaultMaxExportBatchSize,
+	}
+	if o.serverless {
+		snapshot.BatchTimeout = serverlessBatchTimeout
+		snapshot.ExportTimeout = serverlessExportTimeout
+	}
+
+	b := o.batcher
+	if b.batchTimeout > 0 {
+		snapshot.BatchTimeout = b.batchTimeout
+	}
+	if b.exportTimeout > 0 {
+		snapshot.ExportTimeout = b.exportTimeout
+	}
+	if b.maxQueueSize > 0 {
+		snapshot.MaxQueueSize = b.maxQueueSize
+	}
+	if b.maxExportBatchSize > 0 {
+		snapshot.MaxExportBatchSize = b.maxExportBatchSize
+	}
+	snapshot.MaxExportBatchSize = min(snapshot.MaxExportBatchSize, snapshot.MaxQueueSize)
+	return snapshot
+}
+
+// ConfigHandler serves the effective configuration of the default tracer as
+// JSON, e.g. on the debug server.
+func ConfigHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(ConfigSnapshot())
+	})
+}
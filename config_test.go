@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConfigSnapshot(t *testing.T) {
+	initTestTracer(t,
+		WithBearerToken("secret"),
+		WithMaxQueueSize(10),
+		WithMaxExportBatchSize(100),
+		WithResourceAttribute("deployment.environment", "prod"),
+	)
+
+	config := ConfigSnapshot()
+	if config.Noop {
+		t.Error("Noop = true, want false")
+	}
+	if config.Exporter != "custom" {
+		t.Errorf("Exporter = %q, want custom", config.Exporter)
+	}
+	if got := config.Headers["authorization"]; got != redacted {
+		t.Errorf("authorization header = %q, want %q", got, redacted)
+	}
+	if config.Batch.MaxQueueSize != 10 || config.Batch.MaxExportBatchSize != 10 {
+		t.Errorf("Batch = %+v, want queue and batch size 10", config.Batch)
+	}
+	if got := config.Resource["deployment.environment"]; got != "prod" {
+		t.Errorf("deployment.environment = %q, want prod", got)
+	}
+	if len(config.Propagators) == 0 {
+		t.Error("no propagators")
+	}
+
+	w := httptest.NewRecorder()
+	ConfigHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	var served Config
+	if err := json.Unmarshal(w.Body.Bytes(), &served); err != nil {
+		t.Fatal(err)
+	}
+	if served.Headers["authorization"] != redacted || served.Batch != config.Batch {
+		t.Errorf("served config = %+v, want %+v", served, config)
+	}
+}
+
+func TestConfigSnapshotNoop(t *testing.T) {
+	if config := noopTracer.ConfigSnapshot(); !config.Noop {
+		t.Error("Noop = false, want true")
+	}
+}
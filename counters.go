@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (s *span) Count(name string, delta int64) {
+	s.countersMu.Lock()
+	defer s.countersMu.Unlock()
+
+	if s.counters == nil {
+		s.counters = make(map[string]int64)
+	}
+	s.counters[name] += delta
+}
+
+// endCounters sets the counters as attributes of the span.
+func (s *span) endCounters() {
+	s.countersMu.Lock()
+	defer s.countersMu.Unlock()
+
+	if len(s.counters) == 0 {
+		return
+	}
+	attrs := make([]attribute.KeyValue, 0, len(s.counters))
+	for _, name := range slices.Sorted(maps.Keys(s.counters)) {
+		attrs = append(attrs, attribute.Int64(name, s.counters[name]))
+	}
+	s.s.SetAttributes(attrs...)
+}
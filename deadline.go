@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const deadlineRemainingKey = "deadline.remaining_ms"
+
+func (s *span) TrackDeadline(ctx context.Context) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return
+	}
+
+	s.deadline = &deadline
+	s.s.SetAttributes(remainingAttribute(deadline))
+}
+
+// endDeadline adds the "deadline.end" event with the budget left at the span end.
+func (s *span) endDeadline() {
+	if s.deadline == nil {
+		return
+	}
+	s.s.AddEvent("deadline.end", trace.WithAttributes(remainingAttribute(*s.deadline)))
+}
+
+// remainingAttribute returns the time left until deadline in milliseconds.
+// The value is negative when the deadline is already exceeded.
+func remainingAttribute(deadline time.Time) attribute.KeyValue {
+	return attribute.Int64(deadlineRemainingKey, time.Until(deadline).Milliseconds())
+}
+
+// WithDeadlineDiagnostics tags child spans whose context deadline cuts the
+// remaining budget of the parent span by more than margin, e.g. a 100ms per-call
+// timeout within a request with 10s left, with "deadline.cut_ms" (how much
+// earlier the child deadline is) and "deadline.parent_remaining_ms". It helps
+// find misconfigured timeouts from traces alone.
+func WithDeadlineDiagnostics(margin time.Duration) Option {
+	return func(opts *Options) {
+		opts.deadlineMargin = margin
+	}
+}
+
+// deadlineDiagnostics returns the attributes of the child span started with ctx
+// if its deadline cuts the parent's one by more than margin.
+func deadlineDiagnostics(ctx context.Context, parent *span, margin time.Duration) []attribute.KeyValue {
+	if parent == nil || parent.ctxDeadline.IsZero() {
+		return nil
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+
+	cut := parent.ctxDeadline.Sub(deadline)
+	if cut <= margin {
+		return nil
+	}
+	return []attribute.KeyValue{
+		attribute.Int64("deadline.cut_ms", cut.Milliseconds()),
+		attribute.Int64("deadline.parent_remaining_ms", time.Until(parent.ctxDeadline).Milliseconds()),
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTrackDeadline(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration // no deadline if 0
+		tracked bool
+		min     int64 // min remaining ms
+		max     int64 // max remaining ms
+	}{
+		{"no deadline", 0, false, 0, 0},
+		{"remaining", time.Hour, true, time.Hour.Milliseconds() - 1000, time.Hour.Milliseconds()},
+		{"exceeded", -time.Minute, true, -time.Minute.Milliseconds() - 1000, -time.Minute.Milliseconds()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t)
+
+			ctx := context.Background()
+			if tt.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			ctx, span := StartSpan(ctx, "span")
+			span.TrackDeadline(ctx)
+			span.End()
+
+			s := recorder.Ended()[0]
+			remaining, ok := spanAttribute(s, deadlineRemainingKey)
+			if ok != tt.tracked {
+				t.Fatalf("%s recorded = %v, want %v", deadlineRemainingKey, ok, tt.tracked)
+			}
+			if !tt.tracked {
+				if len(s.Events()) != 0 {
+					t.Errorf("events = %v, want none", s.Events())
+				}
+				return
+			}
+			if ms := remaining.AsInt64(); ms < tt.min || ms > tt.max {
+				t.Errorf("%s = %d, want in [%d, %d]", deadlineRemainingKey, ms, tt.min, tt.max)
+			}
+			if events := s.Events(); len(events) != 1 || events[0].Name != "deadline.end" {
+				t.Errorf("events = %v, want deadline.end", events)
+			}
+		})
+	}
+}
+
+func TestDeadlineDiagnostics(t *testing.T) {
+	tests := []struct {
+		name  string
+		child time.Duration // child timeout, the parent one if 0
+		cut   bool
+	}{
+		{"inherited", 0, false},
+		{"within margin", 9*time.Second + 500*time.Millisecond, false},
+		{"cut", 100 * time.Millisecond, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t, WithDeadlineDiagnostics(time.Second))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			ctx, parent := StartSpan(ctx, "request")
+			if tt.child != 0 {
+				ctx, cancel = context.WithTimeout(ctx, tt.child)
+				defer cancel()
+			}
+			_, child := StartSpan(ctx, "call")
+			child.End()
+			parent.End()
+
+			s := recorder.Ended()[0]
+			cut, ok := spanAttribute(s, "deadline.cut_ms")
+			if ok != tt.cut {
+				t.Fatalf("deadline.cut_ms recorded = %v, want %v", ok, tt.cut)
+			}
+			if !tt.cut {
+				return
+			}
+			if ms := cut.AsInt64(); ms < 9800 || ms > 9900 {
+				t.Errorf("deadline.cut_ms = %d, want about 9900", ms)
+			}
+			if remaining, _ := spanAttribute(s, "deadline.parent_remaining_ms"); remaining.AsInt64() < 9000 {
+				t.Errorf("deadline.parent_remaining_ms = %d, want about 10000", remaining.AsInt64())
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DescribeContext returns the human-readable description of the tracing state
+// of ctx (trace ID, span ID, sampled flag, baggage and deadline) for debug logs
+// and panic messages:
+//
+//	trace_id=4bf92f3577b34da6a3ce929d0e0e4736 span_id=00f067aa0ba902b7 sampled=true remote=false baggage="tenant=acme" deadline=1.5s
+func DescribeContext(ctx context.Context) string {
+	var b strings.Builder
+
+	sc := trace.SpanContextFromContext(ctx)
+	if sc.IsValid() {
+		b.WriteString("trace_id=" + sc.TraceID().String())
+		b.WriteString(" span_id=" + sc.SpanID().String())
+		b.WriteString(" sampled=" + strconv.FormatBool(sc.IsSampled()))
+		b.WriteString(" remote=" + strconv.FormatBool(sc.IsRemote()))
+	} else {
+		b.WriteString("trace=none")
+	}
+
+	if bag := baggage.FromContext(ctx); bag.Len() > 0 {
+		b.WriteString(" baggage=" + strconv.Quote(bag.String()))
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		b.WriteString(" deadline=" + time.Until(deadline).Round(time.Millisecond).String())
+	} else {
+		b.WriteString(" deadline=none")
+	}
+
+	if err := ctx.Err(); err != nil {
+		b.WriteString(" err=" + strconv.Quote(err.Error()))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestDescribeContext(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	bag, err := baggage.Parse("tenant=acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := DescribeContext(context.Background()), "trace=none deadline=none"; got != want {
+		t.Errorf("DescribeContext(background) = %q, want %q", got, want)
+	}
+
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	ctx = baggage.ContextWithBaggage(ctx, bag)
+	ctx, cancel := context.WithTimeout(ctx, time.Hour)
+	cancel()
+
+	got := DescribeContext(ctx)
+	for _, want := range []string{
+		"trace_id=0102030405060708090a0b0c0d0e0f10 span_id=0102030405060708 sampled=true remote=true",
+		`baggage="tenant=acme"`,
+		"deadline=",
+		`err="context canceled"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("DescribeContext() = %q, want %q", got, want)
+		}
+	}
+	if strings.Contains(got, "deadline=none") {
+		t.Errorf("DescribeContext() = %q, want the deadline", got)
+	}
+}
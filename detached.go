@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartDetachedSpan starts the root span of background work triggered by the
+// span of ctx (e.g. a goroutine outliving the request), linked to the span
+// instead of being its child, so the work doesn't stretch the request trace.
+// The returned context keeps the values of ctx (e.g. baggage), but isn't
+// canceled with it.
+func StartDetachedSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, *span) {
+	return current().StartDetachedSpan(ctx, name, opts...)
+}
+
+// StartDetachedSpan starts the detached span with the tracer t (see
+// StartDetachedSpan).
+func (t *Tracer) StartDetachedSpan(
+	ctx context.Context, name string, opts ...trace.SpanStartOption,
+) (context.Context, *span) {
+	if parent := trace.SpanContextFromContext(ctx); parent.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: parent}))
+	}
+	// The request ID would make the root span continue the request trace.
+	ctx = withoutRequestID(context.WithoutCancel(ctx))
+	return t.StartSpan(ctx, name, append(opts, trace.WithNewRoot())...)
+}
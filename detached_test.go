@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: MIT
+
+package tracer_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+	"github.com/cdnnow-pro/go-tracer/tracertest"
+)
+
+func TestStartDetachedSpan(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []tracer.Option
+		header http.Header
+	}{
+		{"random trace ID", nil, http.Header{}},
+		{"request ID", []tracer.Option{tracer.WithRequestIDFallback()}, http.Header{"X-Request-Id": {"abc"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracertest.Init(t, tt.opts...)
+
+			ctx, cancel := context.WithCancel(tracer.Extract(context.Background(), tt.header))
+			ctx, request := tracer.StartSpan(ctx, "request")
+			detachedCtx, detached := tracer.StartDetachedSpan(ctx, "background")
+			cancel()
+			detached.End()
+			request.End()
+
+			if detachedCtx.Err() != nil {
+				t.Error("the detached context is canceled with the request")
+			}
+			if detached.TraceId() == request.TraceId() {
+				t.Errorf("the detached span is in the request trace %s", request.TraceId())
+			}
+			for _, s := range tracertest.Spans(t) {
+				if s.Name() != "background" {
+					continue
+				}
+				if links := s.Links(); len(links) != 1 || !links[0].SpanContext.Equal(request.SpanContext()) {
+					t.Errorf("links = %v, want the request span", links)
+				}
+			}
+		})
+	}
+}
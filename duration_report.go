@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// WithDurationReport collects span durations per span name in-process and prints
+// the summary table (count, min, mean, percentiles, max) to w every window and at
+// shutdown, only at shutdown if window is 0. Useful in load tests run without a
+// tracing backend.
+func WithDurationReport(w io.Writer, window time.Duration) Option {
+	return func(opts *Options) {
+		opts.durationReport = &durationReportOptions{w: w, window: window}
+	}
+}
+
+type durationReportOptions struct {
+	w      io.Writer
+	window time.Duration
+}
+
+// durationReporter is the span processor collecting span durations.
+type durationReporter struct {
+	w io.Writer
+
+	mu        sync.Mutex
+	durations map[string][]time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+var _ tracesdk.SpanProcessor = (*durationReporter)(nil)
+
+func newDurationReporter(w io.Writer, window time.Duration) *durationReporter {
+	r := &durationReporter{
+		w:         w,
+		durations: make(map[string][]time.Duration),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	go r.run(window)
+
+	return r
+}
+
+func (r *durationReporter) run(window time.Duration) {
+	defer close(r.done)
+
+	if window <= 0 {
+		<-r.stop
+		return
+	}
+
+	ticker := time.NewTicker(window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.report()
+		}
+	}
+}
+
+func (*durationReporter) OnStart(context.Context, tracesdk.ReadWriteSpan) {}
+
+func (r *durationReporter) OnEnd(s tracesdk.ReadOnlySpan) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.durations[s.Name()] = append(r.durations[s.Name()], duration(s))
+}
+
+func (r *durationReporter) Shutdown(context.Context) error {
+	close(r.stop)
+	<-r.done
+	r.report()
+
+	return nil
+}
+
+func (*durationReporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+// report prints the summary of the collected durations and resets them.
+func (r *durationReporter) report() {
+	r.mu.Lock()
+	collected := r.durations
+	r.durations = make(map[string][]time.Duration, len(collected))
+	r.mu.Unlock()
+
+	if len(collected) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(collected))
+	for name := range collected {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	tw := tabwriter.NewWriter(r.w, 0, 0, 2, ' ', tabwriter.AlignRight) //nolint:mnd
+	_, _ = fmt.Fprintln(tw, "span\tcount\tmin\tmean\tp50\tp95\tp99\tmax\t")
+	for _, name := range names {
+		d := collected[name]
+		slices.Sort(d)
+
+		var total time.Duration
+		for _, v := range d {
+			total += v
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%v\t%v\t%v\t%v\t%v\t%v\t\n",
+			name, len(d), d[0], total/time.Duration(len(d)),
+			percentile(d, 50), percentile(d, 95), percentile(d, 99), d[len(d)-1], //nolint:mnd
+		)
+	}
+	_ = tw.Flush()
+}
+
+// percentile returns the p-th percentile of sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	return sorted[(len(sorted)-1)*p/100]
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// syncBuffer is the buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestDurationReporter(t *testing.T) {
+	start := time.Unix(0, 0)
+	tests := []struct {
+		name     string
+		window   time.Duration
+		periodic bool // reported before shutdown
+	}{
+		{"shutdown only", 0, false},
+		{"negative window", -time.Second, false},
+		{"periodic", time.Millisecond, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out syncBuffer
+			r := newDurationReporter(&out, tt.window)
+			for _, d := range []time.Duration{time.Millisecond, 3 * time.Millisecond, 2 * time.Millisecond} {
+				r.OnEnd(tracetest.SpanStub{Name: "query", StartTime: start, EndTime: start.Add(d)}.Snapshot())
+			}
+
+			time.Sleep(20 * time.Millisecond)
+			if periodic := out.String() != ""; periodic != tt.periodic {
+				t.Errorf("reported before shutdown = %v, want %v", periodic, tt.periodic)
+			}
+
+			r.OnEnd(tracetest.SpanStub{Name: "query", StartTime: start, EndTime: start.Add(time.Millisecond)}.Snapshot())
+			if err := r.Shutdown(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			report := out.String()
+			if !strings.Contains(report, "query") {
+				t.Errorf("report %q has no query spans", report)
+			}
+			if !tt.periodic && !regexp.MustCompile(`query\s+4\s`).MatchString(report) {
+				t.Errorf("report %q doesn't count all spans", report)
+			}
+		})
+	}
+}
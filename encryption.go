@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// encryptedPrefix starts encrypted attribute values: "enc:v1:<key ID>:<data>",
+// where data is the base64url (unpadded) AES-GCM nonce followed by the
+// ciphertext of the value formatted with attribute.Value.Emit.
+const encryptedPrefix = "enc:v1:"
+
+// WithAttributeEncryption encrypts the values of attributes with the keys (of
+// spans, their events and links) before export with AES-GCM (key is 16, 24 or
+// 32 bytes), so sensitive identifiers can be stored in the trace backend but
+// decoded only by tooling holding the key.
+// Encrypted values are strings "enc:v1:<keyID>:<data>", keyID identifies the key
+// for rotation. Init fails if the key is invalid.
+func WithAttributeEncryption(keyID string, key []byte, keys ...string) Option {
+	return func(opts *Options) {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			opts.encryption = &attributeEncryption{err: fmt.Errorf("invalid attribute encryption key: %w", err)}
+			return
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			opts.encryption = &attributeEncryption{err: fmt.Errorf("invalid attribute encryption key: %w", err)}
+			return
+		}
+
+		enc := &attributeEncryption{
+			prefix: encryptedPrefix + keyID + ":",
+			aead:   aead,
+			keys:   make(map[attribute.Key]bool, len(keys)),
+		}
+		for _, k := range keys {
+			enc.keys[attribute.Key(k)] = true
+		}
+		opts.encryption = enc
+	}
+}
+
+type attributeEncryption struct {
+	prefix string
+	aead   cipher.AEAD
+	keys   map[attribute.Key]bool
+	err    error
+}
+
+func (e *attributeEncryption) encrypt(value attribute.Value) attribute.Value {
+	nonce := make([]byte, e.aead.NonceSize(), e.aead.NonceSize()+len(value.Emit())+e.aead.Overhead())
+	_, _ = rand.Read(nonce)
+	sealed := e.aead.Seal(nonce, nonce, []byte(value.Emit()), nil)
+	return attribute.StringValue(e.prefix + base64.RawURLEncoding.EncodeToString(sealed))
+}
+
+// encryptionProcessor encrypts the configured attributes before passing spans to
+// the next processor.
+type encryptionProcessor struct {
+	next tracesdk.SpanProcessor
+	enc  *attributeEncryption
+}
+
+var _ tracesdk.SpanProcessor = encryptionProcessor{}
+
+func (p encryptionProcessor) OnStart(parent context.Context, s tracesdk.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p encryptionProcessor) OnEnd(s tracesdk.ReadOnlySpan) {
+	e := encryptedSpan{ReadOnlySpan: s, events: s.Events(), links: s.Links()}
+	attrs, encrypted := p.encryptAll(s.Attributes())
+	e.attrs = attrs
+
+	eventsCloned := false
+	for i, event := range e.events {
+		if attrs, ok := p.encryptAll(event.Attributes); ok {
+			if !eventsCloned {
+				e.events = slices.Clone(e.events)
+				eventsCloned = true
+			}
+			e.events[i].Attributes = attrs
+		}
+	}
+	linksCloned := false
+	for i, link := range e.links {
+		if attrs, ok := p.encryptAll(link.Attributes); ok {
+			if !linksCloned {
+				e.links = slices.Clone(e.links)
+				linksCloned = true
+			}
+			e.links[i].Attributes = attrs
+		}
+	}
+
+	if encrypted || eventsCloned || linksCloned {
+		s = e
+	}
+	p.next.OnEnd(s)
+}
+
+// encryptAll returns attrs with the configured attributes encrypted, and if any
+// were encrypted.
+func (p encryptionProcessor) encryptAll(attrs []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	encrypted := false
+	for i, kv := range attrs {
+		if !p.enc.keys[kv.Key] {
+			continue
+		}
+		if !encrypted {
+			attrs = slices.Clone(attrs)
+			encrypted = true
+		}
+		attrs[i].Value = p.enc.encrypt(kv.Value)
+	}
+	return attrs, encrypted
+}
+
+func (p encryptionProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p encryptionProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// encryptedSpan is the span with attribute values of the span, its events and
+// links encrypted.
+type encryptedSpan struct {
+	tracesdk.ReadOnlySpan
+
+	attrs  []attribute.KeyValue
+	events []tracesdk.Event
+	links  []tracesdk.Link
+}
+
+func (s encryptedSpan) Attributes() []attribute.KeyValue {
+	return s.attrs
+}
+
+func (s encryptedSpan) Events() []tracesdk.Event {
+	return s.events
+}
+
+func (s encryptedSpan) Links() []tracesdk.Link {
+	return s.links
+}
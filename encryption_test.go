@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestEncryptionProcessor(t *testing.T) {
+	secret := attribute.String("user.id", "42")
+	plain := attribute.String("http.route", "/users/{id}")
+
+	tests := []struct {
+		name string
+		span tracetest.SpanStub
+		// attrs returns the attributes of the exported span to check.
+		attrs func(s tracesdk.ReadOnlySpan) []attribute.KeyValue
+	}{
+		{
+			"span",
+			tracetest.SpanStub{Attributes: []attribute.KeyValue{plain, secret}},
+			func(s tracesdk.ReadOnlySpan) []attribute.KeyValue { return s.Attributes() },
+		},
+		{
+			"event",
+			tracetest.SpanStub{Events: []tracesdk.Event{{Name: "exception", Attributes: []attribute.KeyValue{plain, secret}}}},
+			func(s tracesdk.ReadOnlySpan) []attribute.KeyValue { return s.Events()[0].Attributes },
+		},
+		{
+			"link",
+			tracetest.SpanStub{Links: []tracesdk.Link{{
+				SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{1}}),
+				Attributes:  []attribute.KeyValue{plain, secret},
+			}}},
+			func(s tracesdk.ReadOnlySpan) []attribute.KeyValue { return s.Links()[0].Attributes },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := buildOptions([]Option{WithAttributeEncryption("k1", make([]byte, 16), string(secret.Key))})
+			recorder := tracetest.NewSpanRecorder()
+			p := encryptionProcessor{next: recorder, enc: options.encryption}
+
+			input := tt.span.Snapshot()
+			p.OnEnd(input)
+
+			for _, kv := range tt.attrs(recorder.Ended()[0]) {
+				switch kv.Key {
+				case secret.Key:
+					if !strings.HasPrefix(kv.Value.AsString(), encryptedPrefix+"k1:") {
+						t.Errorf("%s = %q, want encrypted", kv.Key, kv.Value.AsString())
+					}
+				case plain.Key:
+					if kv.Value != plain.Value {
+						t.Errorf("%s = %q, want %q", kv.Key, kv.Value.AsString(), plain.Value.AsString())
+					}
+				}
+			}
+			for _, kv := range tt.attrs(input) {
+				if kv == secret {
+					return
+				}
+			}
+			t.Error("the input span is modified")
+		})
+	}
+}
+
+func TestEncryptionSizeLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		truncated bool
+	}{
+		{"plain value fits", nil, false},
+		{"ciphertext exceeds", []Option{WithAttributeEncryption("k1", make([]byte, 16), "user.id")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			opts := append([]Option{WithExporter(exporter), WithSpanSizeLimit(120), WithAlwaysSample()}, tt.opts...)
+			tr, err := New(context.Background(), "app", "1.0", opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, span := tr.StartSpan(context.Background(), "op")
+			span.s.SetAttributes(attribute.String("user.id", "0123456789"))
+			span.End()
+			t.Cleanup(func() { _ = tr.Shutdown(context.Background()) })
+			if err := tr.Flush(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("exported %d spans, want 1", len(spans))
+			}
+			truncated := false
+			for _, kv := range spans[0].Attributes {
+				truncated = truncated || kv.Key == "span.truncated"
+			}
+			if truncated != tt.truncated {
+				t.Errorf("truncated = %v, want %v (attributes %v)", truncated, tt.truncated, spans[0].Attributes)
+			}
+		})
+	}
+}
+
+func TestAttributeEncryptionDecrypt(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	options := buildOptions([]Option{WithAttributeEncryption("2024-01", key, "user.id")})
+
+	encrypted := options.encryption.encrypt(attribute.Int64Value(42)).AsString()
+	data, ok := strings.CutPrefix(encrypted, "enc:v1:2024-01:")
+	if !ok {
+		t.Fatalf("encrypted = %q, want the key ID prefix", encrypted)
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plain) != "42" {
+		t.Errorf("decrypted = %q, want 42", plain)
+	}
+
+	if again := options.encryption.encrypt(attribute.Int64Value(42)).AsString(); again == encrypted {
+		t.Error("encryption isn't randomized")
+	}
+}
+
+func TestAttributeEncryptionInvalidKey(t *testing.T) {
+	_, err := New(context.Background(), "app", "1.0.0",
+		WithExporter(tracetest.NewNoopExporter()), WithAttributeEncryption("k1", []byte("short"), "user.id"))
+	if err == nil {
+		t.Error("New() with the invalid key succeeded")
+	}
+}
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// WithEnvConfig configures the tracer from the standard OpenTelemetry environment
+// variables, so deployments can reconfigure it without rebuilding binaries:
+//
+//   - OTEL_SDK_DISABLED=true disables the tracer (as Noop);
+//   - OTEL_SERVICE_NAME overrides the application name passed to Init;
+//   - OTEL_RESOURCE_ATTRIBUTES adds resource attributes ("key1=value1,key2=value2");
+//   - OTEL_EXPORTER_OTLP_TRACES_ENDPOINT or OTEL_EXPORTER_OTLP_ENDPOINT sets the
+//     collector host, port (80 or 443 by the scheme if omitted) and OTLP/HTTP
+//     URL path ("https" scheme enables TLS), or the unix socket of the gRPC
+//     collector ("unix:///var/run/otel.sock");
+//   - OTEL_EXPORTER_OTLP_TRACES_PROTOCOL or OTEL_EXPORTER_OTLP_PROTOCOL selects
+//     the exporter ("grpc" or "http/protobuf");
+//   - OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG set the sampler.
+//
+// Explicit options win over the environment regardless of their order. Invalid
+// values are reported to the OpenTelemetry error handler and ignored.
+func WithEnvConfig() Option {
+	return func(opts *Options) {
+		opts.envConfig = true
+	}
+}
+
+// envOptions returns options read from the environment.
+func envOptions() []Option {
+	var opts []Option
+
+	if disabled, _ := strconv.ParseBool(os.Getenv("OTEL_SDK_DISABLED")); disabled {
+		opts = append(opts, Noop())
+	}
+	if name := os.Getenv("OTEL_SERVICE_NAME"); name != "" {
+		opts = append(opts, func(o *Options) {
+			o.serviceName = name
+		})
+	}
+	if attrs := os.Getenv("OTEL_RESOURCE_ATTRIBUTES"); attrs != "" {
+		opts = append(opts, envResourceAttributes(attrs)...)
+	}
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); endpoint != "" {
+		opts = append(opts, envEndpoint(endpoint, true)...)
+	} else if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+		opts = append(opts, envEndpoint(endpoint, false)...)
+	}
+	switch protocol := firstEnv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "OTEL_EXPORTER_OTLP_PROTOCOL"); protocol {
+	case "", "grpc":
+	case "http/protobuf":
+		opts = append(opts, WithHTTPExporter())
+	default:
+		otel.Handle(fmt.Errorf("tracer: unsupported OTLP protocol %q", protocol))
+	}
+	if sampler := os.Getenv("OTEL_TRACES_SAMPLER"); sampler != "" {
+		if s, ok := envSampler(sampler, os.Getenv("OTEL_TRACES_SAMPLER_ARG")); ok {
+			opts = append(opts, WithSampler(s))
+		}
+	}
+
+	return opts
+}
+
+func firstEnv(keys ...string) string {
+	for _, key := range keys {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func envResourceAttributes(s string) []Option {
+	var attrs []attribute.KeyValue
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			otel.Handle(fmt.Errorf("tracer: invalid resource attribute %q", pair))
+			continue
+		}
+		if decoded, err := url.PathUnescape(value); err == nil {
+			value = decoded
+		}
+		attrs = append(attrs, attribute.String(strings.TrimSpace(key), strings.TrimSpace(value)))
+	}
+
+	return []Option{func(o *Options) {
+		o.resourceAttributes = append(o.resourceAttributes, attrs...)
+	}}
+}
+
+// envEndpoint returns options of the OTLP endpoint URL. The path of the traces
+// endpoint is used as is, "/v1/traces" is appended to the path of the base
+// endpoint of all signals. The port defaults to the one of the scheme.
+func envEndpoint(endpoint string, traces bool) []Option {
+	u, err := url.Parse(endpoint)
+	if err == nil && u.Scheme == "unix" {
+		return []Option{WithCollectorEndpoint(endpoint)}
+	}
+	if err != nil || u.Host == "" {
+		otel.Handle(fmt.Errorf("tracer: invalid OTLP endpoint %q", endpoint))
+		return nil
+	}
+
+	opts := []Option{WithCollectorHost(u.Hostname())}
+	switch port := u.Port(); {
+	case port != "":
+		p, err := strconv.ParseUint(port, 10, 16)
+		if err != nil {
+			otel.Handle(fmt.Errorf("tracer: invalid OTLP endpoint port %q", port))
+			return nil
+		}
+		opts = append(opts, WithCollectorPort(uint16(p)))
+	case u.Scheme == "https":
+		opts = append(opts, WithCollectorPort(443))
+	case u.Scheme == "http":
+		opts = append(opts, WithCollectorPort(80))
+	}
+	if u.Scheme == "https" {
+		opts = append(opts, WithTLS(&tls.Config{MinVersion: tls.VersionTLS12}))
+	}
+
+	switch path := u.EscapedPath(); {
+	case traces && path == "":
+		opts = append(opts, WithCollectorURLPath("/"))
+	case traces:
+		opts = append(opts, WithCollectorURLPath(path))
+	case strings.Trim(path, "/") != "":
+		opts = append(opts, WithCollectorURLPath(strings.TrimSuffix(path, "/")+"/v1/traces"))
+	}
+
+	return opts
+}
+
+func envSampler(name, arg string) (tracesdk.Sampler, bool) {
+	ratio := func() (float64, bool) {
+		if arg == "" {
+			return 1, true
+		}
+		r, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			otel.Handle(fmt.Errorf("tracer: invalid sampler argument %q", arg))
+			return 0, false
+		}
+		return r, true
+	}
+
+	switch name {
+	case "always_on":
+		return tracesdk.AlwaysSample(), true
+	case "always_off":
+		return tracesdk.NeverSample(), true
+	case "traceidratio":
+		r, ok := ratio()
+		return tracesdk.TraceIDRatioBased(r), ok
+	case "parentbased_always_on":
+		return tracesdk.ParentBased(tracesdk.AlwaysSample()), true
+	case "parentbased_always_off":
+		return tracesdk.ParentBased(tracesdk.NeverSample()), true
+	case "parentbased_traceidratio":
+		r, ok := ratio()
+		return tracesdk.ParentBased(tracesdk.TraceIDRatioBased(r)), ok
+	default:
+		otel.Handle(fmt.Errorf("tracer: unsupported sampler %q", name))
+		return nil, false
+	}
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestEnvCollectorTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		opts     []Option
+		target   string
+	}{
+		{"unix socket", "unix:///var/run/otel.sock", nil, "unix:///var/run/otel.sock"},
+		{"host and port", "http://collector:4317", nil, "collector:4317"},
+		{"explicit host", "unix:///var/run/otel.sock", []Option{WithCollectorHost("agent")}, "agent:4317"},
+		{"explicit port", "unix:///var/run/otel.sock", []Option{WithCollectorPort(14317)}, "localhost:14317"},
+		{"explicit endpoint", "http://collector:4317", []Option{WithCollectorEndpoint("dns:///agent:4317")}, "dns:///agent:4317"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", tt.endpoint)
+
+			options := buildOptions(append([]Option{WithEnvConfig()}, tt.opts...))
+			if target := options.GetGrpcTarget(); target != tt.target {
+				t.Errorf("target = %q, want %q", target, tt.target)
+			}
+		})
+	}
+}
+
+func TestEnvEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		endpoint string
+		host     string
+		port     uint16
+		urlPath  string
+	}{
+		{"http", "OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector", "collector", 80, ""},
+		{"https", "OTEL_EXPORTER_OTLP_ENDPOINT", "https://collector", "collector", 443, ""},
+		{"port", "OTEL_EXPORTER_OTLP_ENDPOINT", "https://collector:4318/", "collector", 4318, ""},
+		{"base path", "OTEL_EXPORTER_OTLP_ENDPOINT", "https://gateway/otlp/", "gateway", 443, "/otlp/v1/traces"},
+		{"traces path", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "https://gateway/otlp/spans", "gateway", 443, "/otlp/spans"},
+		{"traces root", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "http://collector:4318", "collector", 4318, "/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.env, tt.endpoint)
+
+			options := buildOptions([]Option{WithEnvConfig()})
+			if options.host != tt.host || options.port != tt.port || options.urlPath != tt.urlPath {
+				t.Errorf("host, port, path = %q, %d, %q, want %q, %d, %q",
+					options.host, options.port, options.urlPath, tt.host, tt.port, tt.urlPath)
+			}
+		})
+	}
+}
+
+func TestEnvSampler(t *testing.T) {
+	tests := []struct {
+		name, arg string
+		want      string // sampler description, unsupported if empty
+	}{
+		{"always_on", "", "AlwaysOnSampler"},
+		{"always_off", "", "AlwaysOffSampler"},
+		{"traceidratio", "0.25", "TraceIDRatioBased{0.25}"},
+		{"traceidratio", "", "AlwaysOnSampler"},
+		{"traceidratio", "half", ""},
+		{"parentbased_always_on", "", "ParentBased{root:AlwaysOnSampler"},
+		{"parentbased_always_off", "", "ParentBased{root:AlwaysOffSampler"},
+		{"parentbased_traceidratio", "0.5", "ParentBased{root:TraceIDRatioBased{0.5}"},
+		{"jaeger_remote", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.arg, func(t *testing.T) {
+			sampler, ok := envSampler(tt.name, tt.arg)
+			if ok != (tt.want != "") {
+				t.Fatalf("envSampler() ok = %v, want %v", ok, tt.want != "")
+			}
+			if ok && !strings.HasPrefix(sampler.Description(), tt.want) {
+				t.Errorf("sampler = %s, want %s", sampler.Description(), tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvConfig(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "edge")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "deployment.environment=prod,team=cdn%20core,invalid")
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/protobuf")
+	t.Setenv("OTEL_TRACES_SAMPLER", "always_off")
+
+	options := buildOptions([]Option{WithEnvConfig()})
+	if options.serviceName != "edge" {
+		t.Errorf("service name = %q, want edge", options.serviceName)
+	}
+	want := []attribute.KeyValue{attribute.String("deployment.environment", "prod"), attribute.String("team", "cdn core")}
+	if !slices.Equal(options.resourceAttributes, want) {
+		t.Errorf("resource attributes = %v, want %v", options.resourceAttributes, want)
+	}
+	if !options.httpExporter {
+		t.Error("OTLP/HTTP exporter not selected")
+	}
+	if options.sampler == nil || options.sampler.Description() != "AlwaysOffSampler" {
+		t.Errorf("sampler = %v, want AlwaysOffSampler", options.sampler)
+	}
+
+	// Explicit options win regardless of their order.
+	options = buildOptions([]Option{WithAlwaysSample(), WithEnvConfig()})
+	if options.sampler.Description() != "AlwaysOnSampler" {
+		t.Errorf("sampler = %s, want the explicit AlwaysOnSampler", options.sampler.Description())
+	}
+
+	t.Setenv("OTEL_SDK_DISABLED", "true")
+	if options := buildOptions([]Option{WithEnvConfig()}); !options.IsNoop() {
+		t.Error("OTEL_SDK_DISABLED=true doesn't disable the tracer")
+	}
+}
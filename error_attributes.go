@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	errorAttributersMu sync.RWMutex
+	errorAttributers   []func(error) []attribute.KeyValue
+)
+
+// RegisterErrorAttributer registers the function that returns attributes for
+// errors of type T (matched with errors.As), e.g. the error code or entity ID of
+// a domain error. The attributes are set on the span when End records the error,
+// and attached to the exception event recorded by RecordError.
+//
+// It's intended to be called from init functions.
+func RegisterErrorAttributer[T error](fn func(T) []attribute.KeyValue) {
+	errorAttributersMu.Lock()
+	defer errorAttributersMu.Unlock()
+
+	errorAttributers = append(errorAttributers, func(err error) []attribute.KeyValue {
+		var target T
+		if errors.As(err, &target) {
+			return fn(target)
+		}
+		return nil
+	})
+}
+
+// errorAttributes returns attributes of err from all registered attributers.
+func errorAttributes(err error) []attribute.KeyValue {
+	errorAttributersMu.RLock()
+	defer errorAttributersMu.RUnlock()
+
+	var attrs []attribute.KeyValue
+	for _, fn := range errorAttributers {
+		attrs = append(attrs, fn(err)...)
+	}
+	return attrs
+}
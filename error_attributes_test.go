@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type orderError struct{ id string }
+
+func (e *orderError) Error() string { return "order " + e.id + " failed" }
+
+func init() {
+	RegisterErrorAttributer(func(err *orderError) []attribute.KeyValue {
+		return []attribute.KeyValue{attribute.String("order.id", err.id)}
+	})
+}
+
+func TestRegisterErrorAttributer(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		id   string // no attribute if empty
+	}{
+		{"matched", &orderError{id: "42"}, "42"},
+		{"wrapped", fmt.Errorf("checkout: %w", &orderError{id: "7"}), "7"},
+		{"other type", errors.New("order 42 failed"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t)
+
+			_, span := StartSpan(context.Background(), "span")
+			span.RecordError(tt.err)
+			span.End(&tt.err)
+
+			s := recorder.Ended()[0]
+			v, ok := spanAttribute(s, "order.id")
+			if ok != (tt.id != "") || v.AsString() != tt.id {
+				t.Errorf("span order.id = %q (set %v), want %q", v.AsString(), ok, tt.id)
+			}
+
+			var eventID string
+			for _, e := range s.Events() {
+				for _, kv := range e.Attributes {
+					if e.Name == "exception" && kv.Key == "order.id" {
+						eventID = kv.Value.AsString()
+					}
+				}
+			}
+			if eventID != tt.id {
+				t.Errorf("exception event order.id = %q, want %q", eventID, tt.id)
+			}
+		})
+	}
+}
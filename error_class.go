@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+// ErrorClass defines how Span.End handles an error.
+type ErrorClass int
+
+const (
+	// ErrorClassDefault handles the error as usual: sets the Error status, except
+	// for cancellation errors handled according to the cancel policy.
+	ErrorClassDefault ErrorClass = iota
+	// ErrorClassError sets the Error status, even for cancellation errors.
+	ErrorClassError
+	// ErrorClassEvent adds the "expected_error" event and leaves the status unset.
+	ErrorClassEvent
+	// ErrorClassOk adds the "expected_error" event and sets the Ok status, unless
+	// other errors passed to End are failures.
+	ErrorClassOk
+)
+
+// WithErrorClassifier sets the classifier of errors passed to Span.End, e.g. to
+// keep sql.ErrNoRows or validation errors from marking spans as failed. Errors
+// expected for the span (see Span.ExpectError) aren't classified.
+func WithErrorClassifier(classifier func(error) ErrorClass) Option {
+	return func(opts *Options) {
+		opts.errorClassifier = classifier
+	}
+}
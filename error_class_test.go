@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+var errValidation = errors.New("validation failed")
+
+func TestErrorClassifier(t *testing.T) {
+	classifier := func(err error) ErrorClass {
+		switch {
+		case errors.Is(err, errNotFound):
+			return ErrorClassOk
+		case errors.Is(err, errValidation):
+			return ErrorClassEvent
+		case errors.Is(err, context.Canceled):
+			return ErrorClassError
+		}
+		return ErrorClassDefault
+	}
+
+	tests := []struct {
+		name     string
+		errs     []error
+		status   codes.Code
+		expected bool // "expected_error" event
+	}{
+		{"default", []error{errors.New("boom")}, codes.Error, false},
+		{"ok", []error{errNotFound}, codes.Ok, true},
+		{"event", []error{errValidation}, codes.Unset, true},
+		{"canceled as error", []error{context.Canceled}, codes.Error, false},
+		{"ok with event", []error{errValidation, errNotFound}, codes.Ok, true},
+		{"ok with failure", []error{errNotFound, errors.New("boom")}, codes.Error, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t, WithErrorClassifier(classifier))
+
+			_, span := StartSpan(context.Background(), "span")
+			errs := make([]*error, len(tt.errs))
+			for i := range tt.errs {
+				errs[i] = &tt.errs[i]
+			}
+			span.End(errs...)
+
+			s := recorder.Ended()[0]
+			if s.Status().Code != tt.status {
+				t.Errorf("status = %v, want %v", s.Status().Code, tt.status)
+			}
+			var expected bool
+			for _, e := range s.Events() {
+				expected = expected || e.Name == "expected_error"
+			}
+			if expected != tt.expected {
+				t.Errorf("expected_error event = %v, want %v", expected, tt.expected)
+			}
+		})
+	}
+}
+
+func TestErrorClassifierSkipsExpected(t *testing.T) {
+	recorder := initTestTracer(t, WithErrorClassifier(func(error) ErrorClass { return ErrorClassError }))
+
+	_, span := StartSpan(context.Background(), "span")
+	span.ExpectError(errNotFound)
+	err := errNotFound
+	span.End(&err)
+
+	if s := recorder.Ended()[0]; s.Status().Code != codes.Unset {
+		t.Errorf("status = %v, want Unset for the expected error", s.Status().Code)
+	}
+}
@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExemplarLabels returns exemplar labels ("trace_id" and "span_id") of the span
+// from ctx for exemplar-capable metrics instrumentation, so latency histograms
+// can link to traces:
+//
+//	histogram.(prometheus.ExemplarObserver).ObserveWithExemplar(d, tracer.ExemplarLabels(ctx))
+//
+// Returns nil if the span isn't sampled, since there would be no trace to link to.
+func ExemplarLabels(ctx context.Context) map[string]string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() || !sc.IsSampled() {
+		return nil
+	}
+
+	return map[string]string{
+		"trace_id": sc.TraceID().String(),
+		"span_id":  sc.SpanID().String(),
+	}
+}
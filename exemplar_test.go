@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestExemplarLabels(t *testing.T) {
+	sc := trace.SpanContextConfig{
+		TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+	sampled := sc
+	sampled.TraceFlags = trace.FlagsSampled
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want map[string]string
+	}{
+		{"no span", context.Background(), nil},
+		{"unsampled", trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(sc)), nil},
+		{"sampled", trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(sampled)), map[string]string{
+			"trace_id": "0102030405060708090a0b0c0d0e0f10",
+			"span_id":  "0102030405060708",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExemplarLabels(tt.ctx); !maps.Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+				t.Errorf("ExemplarLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
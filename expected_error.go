@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (s *span) SuppressErrorStatus() {
+	s.suppressErrorStatus = true
+}
+
+func (s *span) ExpectError(err error) {
+	s.expectedErrors = append(s.expectedErrors, err)
+}
+
+// errorClass returns the class of err: ErrorClassEvent if it's expected for
+// this span, otherwise the one returned by the classifier set by
+// WithErrorClassifier.
+func (s *span) errorClass(err error) ErrorClass {
+	if s.suppressErrorStatus || s.isExpected(err) {
+		return ErrorClassEvent
+	}
+	if classifier := s.t.options.errorClassifier; classifier != nil {
+		return classifier(err)
+	}
+	return ErrorClassDefault
+}
+
+// handleExpected adds the "expected_error" event if err of the class isn't a
+// failure. Returns false if the error should be handled as a failure. The Ok
+// status of ErrorClassOk is left to the caller.
+func (s *span) handleExpected(err error, class ErrorClass) bool {
+	if class != ErrorClassEvent && class != ErrorClassOk {
+		return false
+	}
+
+	s.s.AddEvent("expected_error", trace.WithAttributes(
+		semconv.ExceptionMessage(err.Error()),
+		attribute.String(errorFingerprintKey, errorFingerprint(err)),
+	))
+
+	return true
+}
+
+func (s *span) isExpected(err error) bool {
+	for _, expected := range s.expectedErrors {
+		if errors.Is(err, expected) {
+			return true
+		}
+	}
+	return false
+}
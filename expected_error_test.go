@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+var errNotFound = errors.New("not found")
+
+func TestExpectedErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(s Span)
+		err      error
+		status   codes.Code
+		expected bool // "expected_error" event
+	}{
+		{"unexpected", func(Span) {}, errNotFound, codes.Error, false},
+		{"expected", func(s Span) { s.ExpectError(errNotFound) }, errNotFound, codes.Unset, true},
+		{"expected wrapped", func(s Span) { s.ExpectError(errNotFound) }, fmt.Errorf("user: %w", errNotFound), codes.Unset, true},
+		{"other error", func(s Span) { s.ExpectError(errNotFound) }, errors.New("boom"), codes.Error, false},
+		{"suppressed", func(s Span) { s.SuppressErrorStatus() }, errors.New("boom"), codes.Unset, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t)
+
+			_, span := StartSpan(context.Background(), "span")
+			tt.setup(span)
+			span.End(&tt.err)
+
+			s := recorder.Ended()[0]
+			if s.Status().Code != tt.status {
+				t.Errorf("status = %v, want %v", s.Status().Code, tt.status)
+			}
+			var expected bool
+			for _, e := range s.Events() {
+				expected = expected || e.Name == "expected_error"
+			}
+			if expected != tt.expected {
+				t.Errorf("expected_error event = %v, want %v", expected, tt.expected)
+			}
+		})
+	}
+}
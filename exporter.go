@@ -4,38 +4,187 @@ package tracer
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/keepalive"
 )
 
-func makeGrpcExporter(ctx context.Context, options Options) (*otlptrace.Exporter, func() error, error) {
-	conn, err := grpc.NewClient(options.GetGrpcTarget(), grpcDialOptions(options)...)
+// dialCollector creates the gRPC connection to the collector, shared by the
+// signals. Returns nil for OTLP/HTTP and local exporters.
+func dialCollector(options Options) (*grpc.ClientConn, error) {
+	if options.localExporter != nil || options.httpExporter {
+		return nil, nil //nolint:nilnil
+	}
+
+	dialOpts, err := grpcDialOptions(options)
 	if err != nil {
-		return nil, nil, fmt.Errorf("trace collector connection error: %w", err)
+		return nil, err
 	}
-	conn.Connect()
 
-	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithGRPCConn(conn))
+	conn, err := grpc.NewClient(options.GetGrpcTarget(), dialOpts...)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create exporter: %w", err)
+		return nil, fmt.Errorf("trace collector connection error: %w", err)
+	}
+	if !options.lazyConnect {
+		conn.Connect()
 	}
 
-	return exporter, func() error {
-		if err := conn.Close(); err != nil {
-			return fmt.Errorf("failed to close tracer connection: %w", err)
-		}
+	return conn, nil
+}
+
+// makeExporter creates the exporter selected by options, over conn for gRPC.
+func makeExporter(
+	ctx context.Context, options Options, conn *grpc.ClientConn, caps CollectorCapabilities,
+) (tracesdk.SpanExporter, error) {
+	switch {
+	case options.localExporter != nil:
+		return makeLocalExporter(options.localExporter)
+	case options.httpExporter:
+		return makeHTTPExporter(ctx, options)
+	default:
+		return makeGrpcExporter(ctx, options, conn, caps)
+	}
+}
+
+// makeGrpcExporter creates the OTLP exporter over a single long-lived gRPC
+// connection: batches are sent as unary Export calls multiplexed over the same
+// HTTP/2 connection, so there is no per-batch connection overhead.
+//
+// Streaming (OTel Arrow) export isn't supported by otlptracegrpc yet; it can be
+// exposed as an option once the upstream exporter supports it.
+func makeGrpcExporter(
+	ctx context.Context, options Options, conn *grpc.ClientConn, caps CollectorCapabilities,
+) (*otlptrace.Exporter, error) {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithGRPCConn(conn)}
+	if len(options.collectorHeaders) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(options.collectorHeaders))
+	}
+	if options.exportCompression || caps.Gzip {
+		opts = append(opts, otlptracegrpc.WithCompressor(gzip.Name))
+	}
+	if r := options.exportRetry; r != nil {
+		opts = append(opts, otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{
+			Enabled:         true,
+			InitialInterval: r.initial,
+			MaxInterval:     r.maxInterval,
+			MaxElapsedTime:  r.maxElapsed,
+		}))
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exporter: %w", err)
+	}
+
+	return exporter, nil
+}
+
+// closeConn closes the collector connection, if any.
+func closeConn(conn *grpc.ClientConn) error {
+	if conn == nil {
 		return nil
+	}
+	if err := conn.Close(); err != nil {
+		return fmt.Errorf("failed to close tracer connection: %w", err)
+	}
+	return nil
+}
+
+func makeHTTPExporter(ctx context.Context, options Options) (*otlptrace.Exporter, error) {
+	cfg, err := newHTTPExporterConfig(options, "traces")
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(cfg.endpoint),
+	}
+	if cfg.tlsConfig != nil {
+		opts = append(opts, otlptracehttp.WithTLSClientConfig(cfg.tlsConfig))
+	} else {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if cfg.urlPath != "" {
+		opts = append(opts, otlptracehttp.WithURLPath(cfg.urlPath))
+	}
+	if len(cfg.headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.headers))
+	}
+	if cfg.compression {
+		opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	}
+	if r := options.exportRetry; r != nil {
+		opts = append(opts, otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+			Enabled:         true,
+			InitialInterval: r.initial,
+			MaxInterval:     r.maxInterval,
+			MaxElapsedTime:  r.maxElapsed,
+		}))
+	}
+
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exporter: %w", err)
+	}
+
+	return exporter, nil
+}
+
+// httpExporterConfig is the OTLP/HTTP exporter configuration shared by the
+// trace, log and metric exporters.
+type httpExporterConfig struct {
+	endpoint    string
+	urlPath     string // empty for the default path of the signal
+	headers     map[string]string
+	tlsConfig   *tls.Config // nil for plain HTTP
+	compression bool
+}
+
+// newHTTPExporterConfig returns the OTLP/HTTP exporter configuration of the
+// signal: "traces", "logs" or "metrics".
+func newHTTPExporterConfig(options Options, signal string) (httpExporterConfig, error) {
+	tlsConfig, err := clientTLSConfig(options)
+	if err != nil {
+		return httpExporterConfig{}, err
+	}
+
+	urlPath := options.urlPath
+	if urlPath != "" && signal != "traces" {
+		urlPath = strings.TrimSuffix(urlPath, "/v1/traces") + "/v1/" + signal
+	}
+
+	return httpExporterConfig{
+		endpoint:    options.GetHTTPEndpoint(),
+		urlPath:     urlPath,
+		headers:     options.collectorHeaders,
+		tlsConfig:   tlsConfig,
+		compression: options.exportCompression,
 	}, nil
 }
 
-func grpcDialOptions(options Options) []grpc.DialOption {
+func grpcDialOptions(options Options) ([]grpc.DialOption, error) {
+	tlsConfig, err := clientTLSConfig(options)
+	if err != nil {
+		return nil, err
+	}
+
+	transportCredentials := insecure.NewCredentials()
+	if tlsConfig != nil {
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	}
+
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(transportCredentials),
 	}
 
 	var (
@@ -60,5 +209,70 @@ func grpcDialOptions(options Options) []grpc.DialOption {
 		opts = append(opts, grpc.WithKeepaliveParams(keepaliveClientParameters))
 	}
 
+	return opts, nil
+}
+
+// newSpanProcessor returns the processor that passes finished spans to exporter.
+// The batch processor queue is tracked by queue, if not nil.
+func newSpanProcessor(
+	exporter tracesdk.SpanExporter, options Options, queue *exportQueue, cardinality *cardinalityGuard,
+) tracesdk.SpanProcessor {
+	var processor tracesdk.SpanProcessor
+	switch {
+	case options.cliMode:
+		processor = tracesdk.NewSimpleSpanProcessor(exporter)
+	case options.criticalLane:
+		processor = newPriorityProcessor(exporter, options)
+	case queue != nil:
+		exporter = queueExporter{SpanExporter: exporter, queue: queue}
+		processor = queueProcessor{
+			SpanProcessor: tracesdk.NewBatchSpanProcessor(exporter, batcherOptions(options)...),
+			queue:         queue,
+		}
+	default:
+		processor = tracesdk.NewBatchSpanProcessor(exporter, batcherOptions(options)...)
+	}
+
+	// The size guard runs after encryption, so the budget covers the ciphertext.
+	if options.spanSizeLimit > 0 {
+		processor = sizeGuardProcessor{next: processor, budget: options.spanSizeLimit}
+	}
+	if options.encryption != nil {
+		processor = encryptionProcessor{next: processor, enc: options.encryption}
+	}
+	if cardinality != nil {
+		processor = cardinalityProcessor{next: processor, guard: cardinality}
+	}
+	if options.compressionMaxDuration > 0 {
+		processor = newCompressionProcessor(processor, options.compressionMaxDuration)
+	}
+
+	return processor
+}
+
+// batcherOptions returns the batch span processor options: the serverless
+// preset, overridden by the explicit batcher options.
+func batcherOptions(options Options) []tracesdk.BatchSpanProcessorOption {
+	var opts []tracesdk.BatchSpanProcessorOption
+	if options.serverless {
+		opts = append(opts,
+			tracesdk.WithBatchTimeout(serverlessBatchTimeout),
+			tracesdk.WithExportTimeout(serverlessExportTimeout),
+		)
+	}
+
+	b := options.batcher
+	if b.batchTimeout > 0 {
+		opts = append(opts, tracesdk.WithBatchTimeout(b.batchTimeout))
+	}
+	if b.exportTimeout > 0 {
+		opts = append(opts, tracesdk.WithExportTimeout(b.exportTimeout))
+	}
+	if b.maxQueueSize > 0 {
+		opts = append(opts, tracesdk.WithMaxQueueSize(b.maxQueueSize))
+	}
+	if b.maxExportBatchSize > 0 {
+		opts = append(opts, tracesdk.WithMaxExportBatchSize(b.maxExportBatchSize))
+	}
 	return opts
 }
@@ -0,0 +1,336 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// httpCollector is the fake OTLP/HTTP collector recording the export requests.
+type httpCollector struct {
+	mu       sync.Mutex
+	requests map[string]string // path to Content-Encoding
+}
+
+// newHTTPCollector starts the collector and returns the options exporting to it.
+func newHTTPCollector(t *testing.T) (*httpCollector, []Option) {
+	t.Helper()
+
+	c := &httpCollector{requests: make(map[string]string)}
+	return c, serveHTTPCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock()
+		c.requests[r.URL.Path] = r.Header.Get("Content-Encoding")
+		c.mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	})
+}
+
+// serveHTTPCollector starts the OTLP/HTTP collector handler and returns the
+// options exporting to it.
+func serveHTTPCollector(t *testing.T, handler http.HandlerFunc) []Option {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []Option{WithHTTPExporter(), WithCollectorHost(host), WithCollectorPort(uint16(p))}
+}
+
+// encoding returns the Content-Encoding of the request to path, and whether
+// there was one.
+func (c *httpCollector) encoding(path string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	encoding, ok := c.requests[path]
+	return encoding, ok
+}
+
+func TestHTTPExporterConfig(t *testing.T) {
+	tests := []struct {
+		path, signal, want string
+	}{
+		{"", "logs", ""},
+		{"/v1/traces", "traces", "/v1/traces"},
+		{"/otlp/v1/traces", "traces", "/otlp/v1/traces"},
+		{"/otlp/v1/traces", "logs", "/otlp/v1/logs"},
+		{"/otlp/v1/traces", "metrics", "/otlp/v1/metrics"},
+		{"/otlp", "logs", "/otlp/v1/logs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path+" "+tt.signal, func(t *testing.T) {
+			cfg, err := newHTTPExporterConfig(buildOptions([]Option{WithCollectorURLPath(tt.path)}), tt.signal)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.urlPath != tt.want {
+				t.Errorf("urlPath = %q, want %q", cfg.urlPath, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPExporter(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"default path", "", "/v1/traces"},
+		{"custom path", "/otlp/v1/traces", "/otlp/v1/traces"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector, opts := newHTTPCollector(t)
+			opts = append(opts, WithAlwaysSample())
+			if tt.path != "" {
+				opts = append(opts, WithCollectorURLPath(tt.path))
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			tr, err := New(ctx, "app", "1.0.0", opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, span := tr.StartSpan(ctx, "op")
+			span.End()
+			if err := tr.Shutdown(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, ok := collector.encoding(tt.want); !ok {
+				t.Errorf("no spans exported to %s: %v", tt.want, collector.requests)
+			}
+		})
+	}
+}
+
+// recordingExporter records exported spans and whether it was shut down.
+type recordingExporter struct {
+	mu       sync.Mutex
+	spans    []string
+	shutdown bool
+}
+
+func (e *recordingExporter) ExportSpans(_ context.Context, spans []tracesdk.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, s := range spans {
+		e.spans = append(e.spans, s.Name())
+	}
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown = true
+	return nil
+}
+
+func TestAdditionalExporters(t *testing.T) {
+	exporters := []*recordingExporter{{}, {}, {}}
+	tr, err := New(context.Background(), "app", "1.0.0", WithAlwaysSample(), WithExporter(exporters[0]),
+		WithAdditionalExporter(exporters[1]), WithAdditionalExporter(exporters[2]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := tr.StartSpan(context.Background(), "op")
+	span.End()
+	if err := tr.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, e := range exporters {
+		if len(e.spans) != 1 || e.spans[0] != "op" {
+			t.Errorf("exporter %d: spans = %v, want [op]", i, e.spans)
+		}
+		if !e.shutdown {
+			t.Errorf("exporter %d not shut down", i)
+		}
+	}
+}
+
+func TestBatcherOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want tracesdk.BatchSpanProcessorOptions
+	}{
+		{"default", nil, tracesdk.BatchSpanProcessorOptions{}},
+		{"tuned", []Option{
+			WithBatchTimeout(time.Second), WithExportTimeout(2 * time.Second),
+			WithMaxQueueSize(8192), WithMaxExportBatchSize(1024),
+		}, tracesdk.BatchSpanProcessorOptions{
+			BatchTimeout: time.Second, ExportTimeout: 2 * time.Second,
+			MaxQueueSize: 8192, MaxExportBatchSize: 1024,
+		}},
+		{"serverless", []Option{WithServerlessMode()}, tracesdk.BatchSpanProcessorOptions{
+			BatchTimeout: serverlessBatchTimeout, ExportTimeout: serverlessExportTimeout,
+		}},
+		{"serverless overridden", []Option{WithServerlessMode(), WithBatchTimeout(time.Minute)}, tracesdk.BatchSpanProcessorOptions{
+			BatchTimeout: time.Minute, ExportTimeout: serverlessExportTimeout,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got tracesdk.BatchSpanProcessorOptions
+			for _, opt := range batcherOptions(buildOptions(tt.opts)) {
+				opt(&got)
+			}
+			if got != tt.want {
+				t.Errorf("batcher options = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPExportCompressionAndRetry(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		attempts  int
+		encodings []string
+	)
+	opts := serveHTTPCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	})
+	opts = append(opts, WithAlwaysSample(), WithExportCompression(),
+		WithExportRetry(10*time.Millisecond, 10*time.Millisecond, time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tr, err := New(ctx, "app", "1.0.0", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := tr.StartSpan(ctx, "op")
+	span.End()
+	if err := tr.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 2 {
+		t.Errorf("export attempts = %d, want 2", attempts)
+	}
+	for _, encoding := range encodings {
+		if encoding != "gzip" {
+			t.Errorf("Content-Encoding = %q, want gzip", encoding)
+		}
+	}
+}
+
+func TestCollectorHeaders(t *testing.T) {
+	headers := []Option{WithCollectorHeaders(map[string]string{"x-api-key": "key"}), WithBearerToken("secret")}
+	want := map[string]string{"x-api-key": "key", "authorization": "Bearer secret"}
+
+	t.Run("grpc", func(t *testing.T) {
+		collector, addr := startTraceCollector(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		tr, err := New(ctx, "app", "1.0.0", append(headers, WithCollectorEndpoint(addr), WithAlwaysSample())...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, span := tr.StartSpan(ctx, "op")
+		span.End()
+		if err := tr.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		collector.mu.Lock()
+		defer collector.mu.Unlock()
+		for key, value := range want {
+			if got := collector.md.Get(key); len(got) != 1 || got[0] != value {
+				t.Errorf("%s = %v, want [%s]", key, got, value)
+			}
+		}
+	})
+
+	t.Run("http", func(t *testing.T) {
+		var (
+			mu  sync.Mutex
+			got http.Header
+		)
+		opts := serveHTTPCollector(t, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			got = r.Header.Clone()
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/x-protobuf")
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		tr, err := New(ctx, "app", "1.0.0", append(append(headers, opts...), WithAlwaysSample())...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, span := tr.StartSpan(ctx, "op")
+		span.End()
+		if err := tr.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for key, value := range want {
+			if got.Get(key) != value {
+				t.Errorf("%s = %q, want %q", key, got.Get(key), value)
+			}
+		}
+	})
+}
+
+func TestUnixSocketEndpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otel.sock")
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	collector := serveTraceCollector(t, lis)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tr, err := New(ctx, "app", "1.0.0", WithCollectorEndpoint("unix://"+path), WithAlwaysSample())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := tr.StartSpan(ctx, "op")
+	span.End()
+	if err := tr.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	if len(collector.spans) != 1 || collector.spans[0] != "op" {
+		t.Errorf("exported spans = %v, want [op]", collector.spans)
+	}
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExternalClock converts timestamps measured outside the process (eBPF probes,
+// NIC hardware timestamps) to the wall clock of spans.
+type ExternalClock struct {
+	// Base is the wall time of the external clock zero, e.g. the boot time for
+	// CLOCK_MONOTONIC timestamps of eBPF probes (see MonotonicClock).
+	Base time.Time
+
+	// Offset corrects the skew of the external clock relative to the host clock
+	// (e.g. NIC PHC vs system clock), it's added to converted timestamps.
+	Offset time.Duration
+}
+
+// Time converts the external timestamp in nanoseconds to the wall time.
+func (c ExternalClock) Time(ns uint64) time.Time {
+	return c.Base.Add(time.Duration(ns) + c.Offset) //nolint:gosec
+}
+
+func (s *span) AddExternalEvent(name string, clock ExternalClock, ns uint64, attrs ...attribute.KeyValue) {
+	attrs = append(attrs, attribute.Int64("timestamp.external_ns", int64(ns))) //nolint:gosec
+	s.s.AddEvent(name, trace.WithTimestamp(clock.Time(ns)), trace.WithAttributes(attrs...))
+}
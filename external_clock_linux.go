@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+
+package tracer
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// MonotonicClock returns the clock of CLOCK_MONOTONIC timestamps, e.g. taken by
+// bpf_ktime_get_ns in eBPF probes. Its base drifts with wall clock adjustments,
+// so long-running processes should refresh it periodically.
+func MonotonicClock() (ExternalClock, error) {
+	var ts unix.Timespec
+	now := time.Now()
+	if err := unix.ClockGettime(unix.CLOCK_MONOTONIC, &ts); err != nil {
+		return ExternalClock{}, fmt.Errorf("failed to read monotonic clock: %w", err)
+	}
+	return ExternalClock{Base: now.Add(-time.Duration(ts.Nano()))}, nil
+}
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+
+package tracer
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestMonotonicClock(t *testing.T) {
+	clock, err := MonotonicClock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ts unix.Timespec
+	if err := unix.ClockGettime(unix.CLOCK_MONOTONIC, &ts); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(clock.Time(uint64(ts.Nano()))).Abs(); d > time.Second { //nolint:gosec
+		t.Errorf("monotonic timestamp is %v off the wall clock", d)
+	}
+}
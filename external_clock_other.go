@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: MIT
+
+//go:build !linux
+
+package tracer
+
+import "errors"
+
+// MonotonicClock returns the clock of CLOCK_MONOTONIC timestamps. It's
+// supported only on Linux.
+func MonotonicClock() (ExternalClock, error) {
+	return ExternalClock{}, errors.New("monotonic clock is supported only on linux")
+}
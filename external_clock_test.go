@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestAddExternalEvent(t *testing.T) {
+	recorder := initTestTracer(t)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := ExternalClock{Base: base, Offset: -time.Microsecond}
+
+	_, span := StartSpan(context.Background(), "op")
+	span.AddExternalEvent("packet.rx", clock, 1500, attribute.String("iface", "eth0"))
+	span.End()
+
+	events := recorder.Ended()[0].Events()
+	if len(events) != 1 {
+		t.Fatalf("events = %v, want packet.rx", events)
+	}
+	e := events[0]
+	if want := base.Add(500 * time.Nanosecond); !e.Time.Equal(want) {
+		t.Errorf("event time = %v, want %v", e.Time, want)
+	}
+	want := []attribute.KeyValue{attribute.String("iface", "eth0"), attribute.Int64("timestamp.external_ns", 1500)}
+	if len(e.Attributes) != len(want) || e.Attributes[0] != want[0] || e.Attributes[1] != want[1] {
+		t.Errorf("event attributes = %v, want %v", e.Attributes, want)
+	}
+}
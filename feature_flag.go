@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// FlagEvaluation is the result of a feature flag evaluation.
+type FlagEvaluation struct {
+	// Key is the flag key.
+	Key string
+	// Variant is the evaluated variant, e.g. "on" or "blue".
+	Variant string
+	// Reason is why the variant was chosen, e.g. "TARGETING_MATCH" or "DEFAULT".
+	Reason string
+	// Provider is the name of the flag provider, may be empty.
+	Provider string
+}
+
+// RecordFlagEvaluation adds the "feature_flag" event with the flag key, variant,
+// reason and provider to the span of ctx, so behavioral differences between
+// traced requests are explainable. Flag SDK hooks can call it for every
+// evaluation; the OpenFeature hook is provided by the openfeaturetracer package.
+func RecordFlagEvaluation(ctx context.Context, eval FlagEvaluation) {
+	attrs := []attribute.KeyValue{
+		semconv.FeatureFlagKey(eval.Key),
+		semconv.FeatureFlagVariant(eval.Variant),
+	}
+	if eval.Reason != "" {
+		attrs = append(attrs, attribute.String("feature_flag.evaluation.reason", eval.Reason))
+	}
+	if eval.Provider != "" {
+		attrs = append(attrs, semconv.FeatureFlagProviderName(eval.Provider))
+	}
+	SpanFromContext(ctx).AddEvent("feature_flag", trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"maps"
+	"testing"
+)
+
+func TestRecordFlagEvaluation(t *testing.T) {
+	tests := []struct {
+		name string
+		eval FlagEvaluation
+		want map[string]string
+	}{
+		{
+			"full",
+			FlagEvaluation{Key: "new-checkout", Variant: "on", Reason: "TARGETING_MATCH", Provider: "flagd"},
+			map[string]string{
+				"feature_flag.key":               "new-checkout",
+				"feature_flag.variant":           "on",
+				"feature_flag.evaluation.reason": "TARGETING_MATCH",
+				"feature_flag.provider_name":     "flagd",
+			},
+		},
+		{
+			"without reason and provider",
+			FlagEvaluation{Key: "new-checkout", Variant: "off"},
+			map[string]string{"feature_flag.key": "new-checkout", "feature_flag.variant": "off"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t)
+
+			ctx, span := StartSpan(context.Background(), "request")
+			RecordFlagEvaluation(ctx, tt.eval)
+			span.End()
+
+			events := recorder.Ended()[0].Events()
+			if len(events) != 1 || events[0].Name != "feature_flag" {
+				t.Fatalf("events = %v, want feature_flag", events)
+			}
+			attrs := map[string]string{}
+			for _, kv := range events[0].Attributes {
+				attrs[string(kv.Key)] = kv.Value.Emit()
+			}
+			if !maps.Equal(attrs, tt.want) {
+				t.Errorf("attributes = %v, want %v", attrs, tt.want)
+			}
+		})
+	}
+}
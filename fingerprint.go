@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const errorFingerprintKey = "error.fingerprint"
+
+// Variable parts of error messages replaced on normalization, in order.
+var fingerprintReplacers = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`"[^"]*"|'[^']*'`), "<str>"},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<uuid>"},
+	{regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}(:\d+)?\b`), "<addr>"},
+	{regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b|\b[0-9a-f]*\d[0-9a-f]*\b`), "<num>"},
+}
+
+// errorFingerprint returns the stable fingerprint of err: the hash of the root
+// cause type and the error message with variable parts (numbers, IDs, quoted
+// strings, addresses) normalized, so identical failures get the same fingerprint.
+func errorFingerprint(err error) string {
+	msg := err.Error()
+	for _, r := range fingerprintReplacers {
+		msg = r.re.ReplaceAllString(msg, r.repl)
+	}
+
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%T\n%s", rootCause(err), msg)
+
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// rootCause returns the innermost error of the errors.Unwrap chain.
+func rootCause(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
+
+func errorFingerprintAttribute(err error) attribute.KeyValue {
+	return attribute.String(errorFingerprintKey, errorFingerprint(err))
+}
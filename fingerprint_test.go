@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+)
+
+type notFoundError struct{ msg string }
+
+func (e notFoundError) Error() string { return e.msg }
+
+func TestErrorFingerprint(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b error
+		same bool
+	}{
+		{"numbers", errors.New("user 42 not found"), errors.New("user 1337 not found"), true},
+		{"hex", errors.New("object 0xdeadbeef freed"), errors.New("object 0x1f freed"), true},
+		{"uuids", errors.New("order 7c9e6679-7425-40de-944b-e07fc1f90ae7 failed"), errors.New("order 0b3a9f34-91c8-4b2e-a4b9-5d6f8d2c1e0a failed"), true},
+		{"quoted", errors.New(`unknown field "name"`), errors.New(`unknown field 'email'`), true},
+		{"addresses", errors.New("dial tcp 10.0.0.1:5432: refused"), errors.New("dial tcp 192.168.1.20:6543: refused"), true},
+		{"wrapped", fmt.Errorf("open 1.txt: %w", fs.ErrNotExist), fmt.Errorf("open 2.txt: %w", fs.ErrNotExist), true},
+		{"messages", errors.New("user not found"), errors.New("order not found"), false},
+		{"root cause types", errors.New("not found"), notFoundError{"not found"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := errorFingerprint(tt.a), errorFingerprint(tt.b)
+			if (a == b) != tt.same {
+				t.Errorf("fingerprints %s (%v) and %s (%v), want same %v", a, tt.a, b, tt.b, tt.same)
+			}
+		})
+	}
+}
+
+func TestErrorFingerprintAttribute(t *testing.T) {
+	recorder := initTestTracer(t)
+
+	err := errors.New("user 42 not found")
+	_, span := StartSpan(context.Background(), "span")
+	span.End(&err)
+
+	v, ok := spanAttribute(recorder.Ended()[0], errorFingerprintKey)
+	if !ok || v.AsString() != errorFingerprint(err) {
+		t.Errorf("%s = %q, want %q", errorFingerprintKey, v.AsString(), errorFingerprint(err))
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Flush exports the spans (and, with WithMetrics and WithLogs, metrics and logs)
+// of the default tracer ended so far, e.g. at the end of a short-lived job. It
+// doesn't shut the tracer down.
+func Flush(ctx context.Context) error {
+	return current().Flush(ctx)
+}
+
+// Flush exports the spans, metrics and logs of the tracer ended so far.
+func (t *Tracer) Flush(ctx context.Context) error {
+	if t.provider == nil {
+		return nil
+	}
+
+	var errs []error
+	if err := t.provider.ForceFlush(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to flush spans: %w", err))
+	}
+	if t.meterProvider != nil {
+		if err := t.meterProvider.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush metrics: %w", err))
+		}
+	}
+	if t.loggerProvider != nil {
+		if err := t.loggerProvider.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush logs: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// startFlushTicker force-flushes tp every interval until the returned stop
+// function is called.
+func startFlushTicker(tp *tracesdk.TracerProvider, interval time.Duration) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// The next tick retries anyway, so errors are only reported.
+				if err := tp.ForceFlush(ctx); err != nil && ctx.Err() == nil {
+					otel.Handle(fmt.Errorf("tracer: failed to flush spans: %w", err))
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
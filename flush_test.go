@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestFlushTickerReportsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		reported bool
+	}{
+		{"success", nil, false},
+		{"failure", errors.New("collector unavailable"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reported := make(chan error, 16)
+			prev := otel.GetErrorHandler()
+			otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+				select {
+				case reported <- err:
+				default:
+				}
+			}))
+			t.Cleanup(func() { otel.SetErrorHandler(prev) })
+
+			exporter := exportFunc(func(context.Context, []tracesdk.ReadOnlySpan) error { return tt.err })
+			tp := tracesdk.NewTracerProvider(tracesdk.WithBatcher(exporter, tracesdk.WithBatchTimeout(time.Hour)))
+			t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+			_, span := tp.Tracer("").Start(context.Background(), "span")
+			span.End()
+
+			stop := startFlushTicker(tp, time.Millisecond)
+			var err error
+			select {
+			case err = <-reported:
+			case <-time.After(100 * time.Millisecond):
+			}
+			stop()
+
+			if (err != nil) != tt.reported {
+				t.Errorf("reported error = %v, want reported %v", err, tt.reported)
+			}
+			if err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("reported error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestFlush(t *testing.T) {
+	exporter := &recordingExporter{}
+	tr, err := New(context.Background(), "app", "1.0.0", WithExporter(exporter), WithAlwaysSample(),
+		WithBatchTimeout(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = tr.Shutdown(context.Background()) })
+
+	_, span := tr.StartSpan(context.Background(), "job")
+	span.End()
+	if err := tr.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	exporter.mu.Lock()
+	defer exporter.mu.Unlock()
+	if len(exporter.spans) != 1 || exporter.shutdown {
+		t.Errorf("spans = %v, shutdown = %v, want job exported without shutdown", exporter.spans, exporter.shutdown)
+	}
+}
+
+func TestFlushNoop(t *testing.T) {
+	if err := noopTracer.Flush(context.Background()); err != nil {
+		t.Errorf("Flush() = %v, want nil", err)
+	}
+}
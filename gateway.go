@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const defaultTraceIDHeader = "X-Trace-ID"
+
+// GatewayConfig configures GatewayMiddleware.
+type GatewayConfig struct {
+	// TraceIDHeader is the response header with the trace ID, "X-Trace-ID" if
+	// empty.
+	TraceIDHeader string
+
+	// ForceSampleHeader is the request header that forces sampling of the
+	// request when set to "1" or "true". Disabled if empty.
+	ForceSampleHeader string
+
+	// SkipPaths are URL path prefixes of requests that aren't traced (health
+	// checks, metrics etc.).
+	SkipPaths []string
+
+	// Skip reports whether the request isn't traced, in addition to SkipPaths.
+	Skip func(r *http.Request) bool
+
+	// TrustedOrigins are origins of browser applications whose client-generated
+	// trace context is trusted. The trace context of browser requests (the ones
+	// with the Origin header) from other origins isn't honored: the span becomes
+	// a new root linked to the client span, so malicious clients can't pollute
+	// our traces. Requests without the Origin header aren't affected.
+	//
+	// Preflight requests from trusted origins get traceparent and tracestate
+	// added to Access-Control-Allow-Headers, unless the CORS handler overwrites
+	// the header.
+	TrustedOrigins []string
+}
+
+// GatewayMiddleware is the preset for edge and gateway services: Middleware with
+// the trace ID returned in the response header, forced sampling by the request
+// header and filtering of requests that shouldn't be traced.
+func GatewayMiddleware(cfg GatewayConfig) func(http.Handler) http.Handler {
+	mc := middlewareConfig{
+		traceIDHeader:     cfg.TraceIDHeader,
+		forceSampleHeader: cfg.ForceSampleHeader,
+		skip: func(r *http.Request) bool {
+			for _, prefix := range cfg.SkipPaths {
+				if strings.HasPrefix(r.URL.Path, prefix) {
+					return true
+				}
+			}
+			return cfg.Skip != nil && cfg.Skip(r)
+		},
+	}
+	if mc.traceIDHeader == "" {
+		mc.traceIDHeader = defaultTraceIDHeader
+	}
+	mc.trustRemote = func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		return origin == "" || slices.Contains(cfg.TrustedOrigins, origin)
+	}
+
+	return func(next http.Handler) http.Handler {
+		next = allowTraceHeaders(next, cfg.TrustedOrigins)
+		return middleware(next, mc)
+	}
+}
+
+// allowTraceHeaders allows browsers from trustedOrigins to send the trace
+// context headers in CORS requests.
+func allowTraceHeaders(next http.Handler, trustedOrigins []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions &&
+			r.Header.Get("Access-Control-Request-Method") != "" &&
+			slices.Contains(trustedOrigins, r.Header.Get("Origin")) {
+			w.Header().Add("Access-Control-Allow-Headers", "traceparent, tracestate")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
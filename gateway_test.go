@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGatewayMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     GatewayConfig
+		path    string
+		header  http.Header
+		traced  bool
+		sampled bool
+	}{
+		{"traced", GatewayConfig{}, "/api", nil, true, false},
+		{"skipped path", GatewayConfig{SkipPaths: []string{"/health"}}, "/health/live", nil, false, false},
+		{"skipped func", GatewayConfig{Skip: func(r *http.Request) bool { return r.URL.Path == "/metrics" }}, "/metrics", nil, false, false},
+		{"forced sampling", GatewayConfig{ForceSampleHeader: "X-Force-Sample"}, "/api", http.Header{"X-Force-Sample": {"1"}}, true, true},
+		{"forced sampling disabled", GatewayConfig{}, "/api", http.Header{"X-Force-Sample": {"1"}}, true, false},
+		{"custom header", GatewayConfig{TraceIDHeader: "X-Request-Trace"}, "/api", nil, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t, WithNeverSample())
+
+			var traced bool
+			handler := GatewayMiddleware(tt.cfg)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				traced = SpanFromContext(r.Context()).IsValid()
+			}))
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for key, values := range tt.header {
+				r.Header[key] = values
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if traced != tt.traced {
+				t.Errorf("traced = %v, want %v", traced, tt.traced)
+			}
+			if sampled := len(recorder.Ended()) == 1; sampled != tt.sampled {
+				t.Errorf("sampled = %v, want %v", sampled, tt.sampled)
+			}
+			header := tt.cfg.TraceIDHeader
+			if header == "" {
+				header = defaultTraceIDHeader
+			}
+			if got := w.Header().Get(header) != ""; got != tt.traced {
+				t.Errorf("%s set = %v, want %v", header, got, tt.traced)
+			}
+		})
+	}
+}
+
+func TestGatewayTrustedOrigins(t *testing.T) {
+	tests := []struct {
+		name   string
+		origin string
+		child  bool // the span continues the client trace
+	}{
+		{"no origin", "", true},
+		{"trusted origin", "https://app.example.com", true},
+		{"untrusted origin", "https://evil.example.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t)
+
+			handler := GatewayMiddleware(GatewayConfig{TrustedOrigins: []string{"https://app.example.com"}})(
+				http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+			r := httptest.NewRequest(http.MethodGet, "/api", nil)
+			r.Header.Set("Traceparent", traceparent)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("ended %d spans, want 1", len(spans))
+			}
+			s := spans[0]
+			if child := s.Parent().IsValid(); child != tt.child {
+				t.Errorf("child of the client span = %v, want %v", child, tt.child)
+			}
+			if tt.child {
+				return
+			}
+			if s.SpanContext().TraceID().String() == "0102030405060708090a0b0c0d0e0f10" {
+				t.Error("client trace ID not re-rooted")
+			}
+			links := s.Links()
+			if len(links) != 1 || links[0].SpanContext.SpanID().String() != "0102030405060708" {
+				t.Errorf("links = %v, want the client span", links)
+			}
+		})
+	}
+}
+
+func TestGatewayPreflight(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		origin string
+		want   string
+	}{
+		{"trusted origin", http.MethodOptions, "https://app.example.com", "traceparent, tracestate"},
+		{"untrusted origin", http.MethodOptions, "https://evil.example.com", ""},
+		{"not preflight", http.MethodGet, "https://app.example.com", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestTracer(t)
+
+			handler := GatewayMiddleware(GatewayConfig{TrustedOrigins: []string{"https://app.example.com"}})(
+				http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+			r := httptest.NewRequest(tt.method, "/api", nil)
+			r.Header.Set("Origin", tt.origin)
+			r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if got := w.Header().Get("Access-Control-Allow-Headers"); got != tt.want {
+				t.Errorf("Access-Control-Allow-Headers = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
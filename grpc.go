@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const defaultTraceIDTrailer = "x-trace-id"
+
+type InterceptorOption func(opts *interceptorOptions)
+
+type interceptorOptions struct {
+	traceIDTrailer string
+}
+
+// WithTraceIDTrailer makes server interceptors attach the trace ID to the
+// trailing metadata of failed calls, so clients can display "reference trace X".
+// The key is "x-trace-id" if not specified.
+func WithTraceIDTrailer(key ...string) InterceptorOption {
+	return func(opts *interceptorOptions) {
+		opts.traceIDTrailer = defaultTraceIDTrailer
+		if len(key) > 0 {
+			opts.traceIDTrailer = key[0]
+		}
+	}
+}
+
+func buildInterceptorOptions(opts []InterceptorOption) interceptorOptions {
+	options := interceptorOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// UnaryServerInterceptor traces incoming unary calls: it continues the trace
+// context from the incoming metadata, starts the server span and sets its status
+// from the returned error (see Span.SetGRPCStatus).
+func UnaryServerInterceptor(opts ...InterceptorOption) grpc.UnaryServerInterceptor {
+	options := buildInterceptorOptions(opts)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, span := startServerSpan(ctx, info.FullMethod)
+
+		var resp any
+		err := injectFault(ctx, FaultGRPCServer, span)
+		if err == nil {
+			resp, err = handler(ctx, req)
+		}
+		endServerSpan(ctx, span, err, options)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor traces incoming streaming calls the same way as
+// UnaryServerInterceptor.
+func StreamServerInterceptor(opts ...InterceptorOption) grpc.StreamServerInterceptor {
+	options := buildInterceptorOptions(opts)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+
+		err := injectFault(ctx, FaultGRPCServer, span)
+		if err == nil {
+			err = handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		}
+		endServerSpan(ctx, span, err, options)
+
+		return err
+	}
+}
+
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, *span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = ExtractCarrier(ctx, metadataCarrier(md))
+
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(rpcAttributes(fullMethod)...),
+	}
+
+	in := Inbound{Header: metadataCarrier(md).Get}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		in.RemoteAddr = p.Addr.String()
+	}
+	if !trustsInbound(in) {
+		opts = append(opts, untrustedParentOptions(ctx)...)
+		ctx = withoutRequestID(ctx)
+	}
+	ctx = stripInboundFault(ctx, in, true)
+
+	return StartSpan(ctx, strings.TrimPrefix(fullMethod, "/"), opts...)
+}
+
+func endServerSpan(ctx context.Context, span *span, err error, options interceptorOptions) {
+	if err != nil && options.traceIDTrailer != "" && span.IsValid() {
+		_ = grpc.SetTrailer(ctx, metadata.Pairs(options.traceIDTrailer, span.TraceId()))
+	}
+	span.SetGRPCStatus(err)
+	span.End()
+}
+
+// rpcAttributes returns the rpc semconv attributes of the "/package.Service/Method"
+// full method name.
+func rpcAttributes(fullMethod string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if ok {
+		attrs = append(attrs, semconv.RPCService(service), semconv.RPCMethod(method))
+	}
+	return attrs
+}
+
+// serverStream overrides the stream context with the one carrying the span.
+type serverStream struct {
+	grpc.ServerStream
+
+	ctx context.Context //nolint:containedctx
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// metadataCarrier adapts gRPC metadata to propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+var _ propagation.TextMapCarrier = metadataCarrier{}
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
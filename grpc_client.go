@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryClientInterceptor traces outgoing unary calls: it starts the client span,
+// injects the trace context into the outgoing metadata and sets the span status
+// from the returned error (see Span.SetGRPCStatus).
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context, method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		ctx, span := startClientSpan(ctx, method)
+
+		err := injectFault(ctx, FaultGRPCClient, span)
+		if err == nil {
+			err = invoker(ctx, method, req, reply, cc, opts...)
+		}
+		span.SetGRPCStatus(err)
+		span.End()
+
+		return err
+	}
+}
+
+// StreamClientInterceptor traces outgoing streaming calls the same way as
+// UnaryClientInterceptor. The span ends when the stream is finished: receiving
+// fails (io.EOF means the OK status), the only response of a client-streaming
+// RPC is received, or the stream fails to start.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+		method string, streamer grpc.Streamer, opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		ctx, span := startClientSpan(ctx, method)
+
+		err := injectFault(ctx, FaultGRPCClient, span)
+		if err != nil {
+			span.SetGRPCStatus(err)
+			span.End()
+			return nil, err
+		}
+
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			span.SetGRPCStatus(err)
+			span.End()
+			return nil, err
+		}
+
+		return &clientStream{ClientStream: cs, span: span, unaryResponse: !desc.ServerStreams}, nil
+	}
+}
+
+func startClientSpan(ctx context.Context, fullMethod string) (context.Context, *span) {
+	ctx, span := StartSpan(ctx, strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(rpcAttributes(fullMethod)...),
+	)
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	InjectCarrier(ctx, metadataCarrier(md))
+
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// clientStream ends the span when the stream is finished.
+type clientStream struct {
+	grpc.ClientStream
+
+	span *span
+	once sync.Once
+	// unaryResponse is set if the server sends a single response, so the stream
+	// is finished once it's received (no io.EOF follows).
+	unaryResponse bool
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil || s.unaryResponse {
+		s.end(err)
+	}
+	return err
+}
+
+func (s *clientStream) Header() (metadata.MD, error) {
+	md, err := s.ClientStream.Header()
+	if err != nil {
+		s.end(err)
+	}
+	return md, err
+}
+
+func (s *clientStream) end(err error) {
+	s.once.Do(func() {
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		s.span.SetGRPCStatus(err)
+		s.span.End()
+	})
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: MIT
+
+package tracer_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+	"github.com/cdnnow-pro/go-tracer/tracertest"
+)
+
+// fakeStream receives responses, then io.EOF.
+type fakeStream struct {
+	grpc.ClientStream
+
+	responses int
+}
+
+func (s *fakeStream) RecvMsg(any) error {
+	if s.responses == 0 {
+		return io.EOF
+	}
+	s.responses--
+	return nil
+}
+
+func TestStreamClientInterceptorEndsSpan(t *testing.T) {
+	tests := []struct {
+		name  string
+		desc  grpc.StreamDesc
+		recvs int // RecvMsg calls made by the client
+		ended bool
+	}{
+		{"client streaming", grpc.StreamDesc{ClientStreams: true}, 1, true},
+		{"server streaming before EOF", grpc.StreamDesc{ServerStreams: true}, 2, false},
+		{"server streaming at EOF", grpc.StreamDesc{ServerStreams: true}, 3, true},
+		{"bidi streaming at EOF", grpc.StreamDesc{ClientStreams: true, ServerStreams: true}, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracertest.Init(t)
+
+			streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+				return &fakeStream{responses: 2}, nil
+			}
+			cs, err := tracer.StreamClientInterceptor()(context.Background(), &tt.desc, nil, "/svc/Method", streamer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for range tt.recvs {
+				_ = cs.RecvMsg(nil)
+			}
+
+			if ended := len(tracertest.Spans(t)) == 1; ended != tt.ended {
+				t.Errorf("span ended = %v, want %v", ended, tt.ended)
+			}
+		})
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status codes.Code
+	}{
+		{"ok", nil, codes.Unset},
+		{"not found", status.Error(grpccodes.NotFound, "no"), codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracertest.Init(t)
+
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "x-tenant", "acme")
+			var md metadata.MD
+			invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				md, _ = metadata.FromOutgoingContext(ctx)
+				return tt.err
+			}
+			if err := tracer.UnaryClientInterceptor()(ctx, "/pkg.Service/Method", nil, nil, nil, invoker); err != tt.err { //nolint:errorlint
+				t.Errorf("err = %v, want %v", err, tt.err)
+			}
+
+			tracertest.AssertSpan(t, "pkg.Service/Method").
+				WithTag("rpc.service", "pkg.Service").
+				WithTag("rpc.method", "Method").
+				WithStatus(tt.status)
+			s := tracertest.Spans(t)[0]
+			if s.SpanKind() != trace.SpanKindClient {
+				t.Errorf("span kind = %v, want client", s.SpanKind())
+			}
+			want := "00-" + s.SpanContext().TraceID().String() + "-" + s.SpanContext().SpanID().String() + "-01"
+			if got := md.Get("traceparent"); len(got) != 1 || got[0] != want {
+				t.Errorf("traceparent = %v, want %s", got, want)
+			}
+			if got := md.Get("x-tenant"); len(got) != 1 {
+				t.Errorf("outgoing metadata x-tenant = %v, want kept", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *span) SetGRPCStatus(err error) {
+	st, ok := status.FromError(err)
+	if !ok && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		// Raw context errors would be Unknown, not Canceled or DeadlineExceeded.
+		st = status.FromContextError(err)
+	}
+	s.s.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+	if grpcCodeIsError(st.Code(), s.kind()) {
+		s.s.SetStatus(codes.Error, st.Message())
+	}
+}
+
+// grpcCodeIsError implements the semconv gRPC span status rules: all non-OK codes
+// are errors for client spans, while for server spans only the codes indicating
+// a server failure are.
+func grpcCodeIsError(code grpccodes.Code, kind trace.SpanKind) bool {
+	if code == grpccodes.OK {
+		return false
+	}
+	if kind != trace.SpanKindServer {
+		return true
+	}
+
+	switch code {
+	case grpccodes.Unknown,
+		grpccodes.DeadlineExceeded,
+		grpccodes.Unimplemented,
+		grpccodes.Internal,
+		grpccodes.Unavailable,
+		grpccodes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
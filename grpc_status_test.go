@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSetGRPCStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   trace.SpanKind
+		err    error
+		code   grpccodes.Code
+		status codes.Code
+	}{
+		{"ok", trace.SpanKindServer, nil, grpccodes.OK, codes.Unset},
+		{"server not found", trace.SpanKindServer, status.Error(grpccodes.NotFound, "no user"), grpccodes.NotFound, codes.Unset},
+		{"client not found", trace.SpanKindClient, status.Error(grpccodes.NotFound, "no user"), grpccodes.NotFound, codes.Error},
+		{"server internal", trace.SpanKindServer, status.Error(grpccodes.Internal, "boom"), grpccodes.Internal, codes.Error},
+		{"server canceled", trace.SpanKindServer, context.Canceled, grpccodes.Canceled, codes.Unset},
+		{"server wrapped canceled", trace.SpanKindServer, fmt.Errorf("query: %w", context.Canceled), grpccodes.Canceled, codes.Unset},
+		{"server deadline", trace.SpanKindServer, context.DeadlineExceeded, grpccodes.DeadlineExceeded, codes.Error},
+		{"server plain error", trace.SpanKindServer, errors.New("boom"), grpccodes.Unknown, codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t)
+
+			_, span := StartSpan(context.Background(), "rpc", trace.WithSpanKind(tt.kind))
+			span.SetGRPCStatus(tt.err)
+			span.s.End()
+
+			s := recorder.Ended()[0]
+			if st := s.Status().Code; st != tt.status {
+				t.Errorf("status = %v, want %v", st, tt.status)
+			}
+			for _, kv := range s.Attributes() {
+				if kv.Key == semconv.RPCGRPCStatusCodeKey && grpccodes.Code(kv.Value.AsInt64()) != tt.code { //nolint:gosec
+					t.Errorf("code = %v, want %v", grpccodes.Code(kv.Value.AsInt64()), tt.code) //nolint:gosec
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// transportStream records the trailer set by the interceptors.
+type transportStream struct {
+	grpc.ServerTransportStream
+
+	trailer metadata.MD
+}
+
+func (s *transportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+// testServerStream is the stream of a call with the incoming context.
+type testServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context //nolint:containedctx
+}
+
+func (s testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []InterceptorOption
+		err     error
+		status  codes.Code
+		trailer string // trailer key expected to carry the trace ID, none if empty
+	}{
+		{"ok", []InterceptorOption{WithTraceIDTrailer()}, nil, codes.Unset, ""},
+		{"error without trailer", nil, status.Error(grpccodes.Internal, "boom"), codes.Error, ""},
+		{"error", []InterceptorOption{WithTraceIDTrailer()}, status.Error(grpccodes.Internal, "boom"), codes.Error, "x-trace-id"},
+		{"client error", []InterceptorOption{WithTraceIDTrailer()}, status.Error(grpccodes.NotFound, "no"), codes.Unset, "x-trace-id"},
+		{"custom key", []InterceptorOption{WithTraceIDTrailer("trace-ref")}, status.Error(grpccodes.Internal, "boom"), codes.Error, "trace-ref"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t)
+			stream := &transportStream{}
+			ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+			var handlerSpan trace.SpanContext
+			handler := func(ctx context.Context, _ any) (any, error) {
+				handlerSpan = trace.SpanContextFromContext(ctx)
+				return nil, tt.err
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: "/pkg.Service/Method"}
+			if _, err := UnaryServerInterceptor(tt.opts...)(ctx, nil, info, handler); err != tt.err { //nolint:errorlint
+				t.Errorf("err = %v, want %v", err, tt.err)
+			}
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("ended %d spans, want 1", len(spans))
+			}
+			s := spans[0]
+			if s.Name() != "pkg.Service/Method" || s.SpanKind() != trace.SpanKindServer {
+				t.Errorf("span = %s %v, want pkg.Service/Method server", s.Name(), s.SpanKind())
+			}
+			if v, _ := spanAttribute(s, semconv.RPCServiceKey); v.AsString() != "pkg.Service" {
+				t.Errorf("rpc.service = %q, want pkg.Service", v.AsString())
+			}
+			if v, _ := spanAttribute(s, semconv.RPCMethodKey); v.AsString() != "Method" {
+				t.Errorf("rpc.method = %q, want Method", v.AsString())
+			}
+			if s.Status().Code != tt.status {
+				t.Errorf("status = %v, want %v", s.Status().Code, tt.status)
+			}
+			if handlerSpan.SpanID() != s.SpanContext().SpanID() {
+				t.Error("handler context doesn't carry the server span")
+			}
+
+			if tt.trailer == "" {
+				if len(stream.trailer) > 0 {
+					t.Errorf("trailer = %v, want none", stream.trailer)
+				}
+			} else if got := stream.trailer.Get(tt.trailer); len(got) != 1 || got[0] != s.SpanContext().TraceID().String() {
+				t.Errorf("trailer %q = %v, want the trace ID", tt.trailer, got)
+			}
+		})
+	}
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	recorder := initTestTracer(t)
+	stream := &transportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("traceparent", traceparent))
+
+	var handlerSpan trace.SpanContext
+	handler := func(_ any, ss grpc.ServerStream) error {
+		handlerSpan = trace.SpanContextFromContext(ss.Context())
+		return status.Error(grpccodes.Unavailable, "down")
+	}
+	info := &grpc.StreamServerInfo{FullMethod: "/pkg.Service/Stream"}
+	if err := StreamServerInterceptor(WithTraceIDTrailer())(nil, testServerStream{ctx: ctx}, info, handler); err == nil {
+		t.Error("handler error not returned")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended %d spans, want 1", len(spans))
+	}
+	s := spans[0]
+	if s.Parent().SpanID().String() != "0102030405060708" {
+		t.Errorf("parent = %s, want the incoming traceparent", s.Parent().SpanID())
+	}
+	if handlerSpan.SpanID() != s.SpanContext().SpanID() {
+		t.Error("stream context doesn't carry the server span")
+	}
+	if s.Status().Code != codes.Error {
+		t.Errorf("status = %v, want Error", s.Status().Code)
+	}
+	if got := stream.trailer.Get("x-trace-id"); len(got) != 1 || got[0] != "0102030405060708090a0b0c0d0e0f10" {
+		t.Errorf("trailer = %v, want the trace ID", got)
+	}
+}
@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware traces incoming HTTP requests: it continues the trace context from
+// the request headers, starts the server span and sets its status from the
+// response status code (see Span.SetHTTPStatus).
+//
+// The span is named after the request method and, when next is http.ServeMux,
+// the matched route pattern.
+func Middleware(next http.Handler) http.Handler {
+	return middleware(next, middlewareConfig{})
+}
+
+type middlewareConfig struct {
+	traceIDHeader     string
+	forceSampleHeader string
+	skip              func(r *http.Request) bool
+	// trustRemote reports whether the extracted remote parent is honored,
+	// otherwise the span becomes a new root linked to it.
+	trustRemote func(r *http.Request) bool
+}
+
+func middleware(next http.Handler, cfg middlewareConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cfg.skip != nil && cfg.skip(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := ExtractCarrier(r.Context(), propagation.HeaderCarrier(r.Header))
+		if cfg.forceSampleHeader != "" && isTruthy(r.Header.Get(cfg.forceSampleHeader)) {
+			ctx = ForceSampling(ctx)
+		}
+
+		opts := []trace.SpanStartOption{
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+				semconv.URLScheme(requestScheme(r)),
+				semconv.ServerAddress(r.Host),
+				semconv.UserAgentOriginal(r.UserAgent()),
+			),
+		}
+		in := Inbound{RemoteAddr: r.RemoteAddr, Header: r.Header.Get}
+		trustRemote := cfg.trustRemote == nil || cfg.trustRemote(r)
+		if !trustsInbound(in) || !trustRemote {
+			opts = append(opts, untrustedParentOptions(ctx)...)
+			ctx = withoutRequestID(ctx)
+		}
+		ctx = stripInboundFault(ctx, in, trustRemote)
+
+		ctx, span := StartSpan(ctx, r.Method, opts...)
+		defer span.End()
+
+		if cfg.traceIDHeader != "" && span.IsValid() {
+			w.Header().Set(cfg.traceIDHeader, span.TraceId())
+		}
+
+		if err := injectFault(ctx, FaultHTTPServer, span); err != nil {
+			span.RecordError(err)
+			span.SetHTTPStatus(http.StatusServiceUnavailable)
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		rw := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		r = r.WithContext(ctx)
+		next.ServeHTTP(rw, r)
+
+		if route := patternRoute(r.Pattern); route != "" {
+			span.s.SetName(r.Method + " " + route)
+			span.s.SetAttributes(semconv.HTTPRoute(route))
+		}
+		// The response of the hijacked connection (e.g. the WebSocket upgrade)
+		// isn't seen by the recorder.
+		if !rw.hijacked || rw.wroteHeader {
+			span.SetHTTPStatus(rw.status)
+		}
+	})
+}
+
+// patternRoute returns the path of the http.ServeMux pattern
+// "[METHOD ][HOST]/[PATH]", e.g. "/x/{id}" of "GET example.com/x/{id}".
+func patternRoute(pattern string) string {
+	if i := strings.IndexAny(pattern, " \t"); i >= 0 {
+		pattern = strings.TrimLeft(pattern[i:], " \t")
+	}
+	if i := strings.IndexByte(pattern, '/'); i >= 0 {
+		return pattern[i:]
+	}
+	return ""
+}
+
+// untrustedParentOptions returns options starting the span as a new root linked
+// to the remote parent from ctx, instead of its child.
+func untrustedParentOptions(ctx context.Context) []trace.SpanStartOption {
+	remote := trace.SpanContextFromContext(ctx)
+	if !remote.IsValid() {
+		return nil
+	}
+
+	return []trace.SpanStartOption{
+		trace.WithNewRoot(),
+		trace.WithLinks(trace.Link{
+			SpanContext: remote,
+			Attributes:  []attribute.KeyValue{attribute.Bool("trace.parent.untrusted", true)},
+		}),
+	}
+}
+
+// statusRecorder records the response status code. It implements http.Flusher
+// and http.Hijacker for handlers asserting them directly (SSE, WebSockets).
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status      int
+	wroteHeader bool
+	hijacked    bool
+}
+
+func (w *statusRecorder) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.status = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *statusRecorder) Flush() {
+	w.wroteHeader = true
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err == nil {
+		w.hijacked = true
+	}
+	return conn, rw, err //nolint:wrapcheck
+}
+
+// Unwrap allows http.ResponseController to access the underlying writer.
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func requestScheme(r *http.Request) string {
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+func isTruthy(s string) bool {
+	switch s {
+	case "1", "true", "TRUE", "True", "yes":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Transport returns the http.RoundTripper tracing outgoing requests: it starts
+// the client span per request, injects the trace context into the request
+// headers and sets the span status from the response status code (see
+// Span.SetHTTPStatus) or the transport error. The span ends when the response
+// headers are received.
+//
+// base is http.DefaultTransport if nil.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(r *http.Request) (resp *http.Response, err error) {
+	ctx, span := StartSpan(r.Context(), r.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(r.Method),
+			semconv.URLFull(redactedURL(r)),
+			semconv.ServerAddress(r.URL.Hostname()),
+		),
+	)
+	defer span.End(&err)
+
+	if port, err := strconv.Atoi(r.URL.Port()); err == nil {
+		span.s.SetAttributes(semconv.ServerPort(port))
+	}
+
+	if err := injectFault(ctx, FaultHTTPClient, span); err != nil {
+		return nil, err
+	}
+
+	// RoundTripper must not modify the request.
+	r = r.Clone(ctx)
+	InjectCarrier(ctx, propagation.HeaderCarrier(r.Header))
+
+	resp, err = t.base.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetHTTPStatus(resp.StatusCode)
+	return resp, nil
+}
+
+// redactedURL returns the request URL without user credentials.
+func redactedURL(r *http.Request) string {
+	if r.URL.User == nil {
+		return r.URL.String()
+	}
+	u := *r.URL
+	u.User = nil
+	return u.String()
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// roundTripFunc is the http.RoundTripper function.
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestTransport(t *testing.T) {
+	recorder := initTestTracer(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	u := strings.Replace(server.URL, "http://", "http://user:pass@", 1) + "/x"
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, u, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := (&http.Client{Transport: Transport(nil)}).Do(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	if r.Header.Get("Traceparent") != "" {
+		t.Error("the request passed to the transport was modified")
+	}
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended %d spans, want 1", len(spans))
+	}
+	s := spans[0]
+	if s.SpanKind() != trace.SpanKindClient || s.Status().Code != codes.Error {
+		t.Errorf("span kind, status = %v, %v, want client, Error", s.SpanKind(), s.Status().Code)
+	}
+	if want := "00-" + s.SpanContext().TraceID().String() + "-" + s.SpanContext().SpanID().String() + "-01"; traceparent != want {
+		t.Errorf("traceparent = %q, want %q", traceparent, want)
+	}
+	if v, _ := spanAttribute(s, semconv.URLFullKey); strings.Contains(v.AsString(), "pass") {
+		t.Errorf("url.full = %q, want without credentials", v.AsString())
+	}
+	if v, _ := spanAttribute(s, semconv.HTTPResponseStatusCodeKey); v.AsInt64() != http.StatusInternalServerError {
+		t.Errorf("status code = %d, want 500", v.AsInt64())
+	}
+	if _, ok := spanAttribute(s, semconv.ServerPortKey); !ok {
+		t.Error("server.port not set")
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	recorder := initTestTracer(t)
+	errDial := errors.New("connection refused")
+
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errDial
+	})
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://origin/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Transport(base).RoundTrip(r); !errors.Is(err, errDial) {
+		t.Errorf("RoundTrip() error = %v, want %v", err, errDial)
+	}
+
+	s := recorder.Ended()[0]
+	if s.Status().Code != codes.Error || s.Status().Description != errDial.Error() {
+		t.Errorf("status = %v, want Error %q", s.Status(), errDial)
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const maxRedirects = 10
+
+// sensitiveHeaders are removed from requests redirected to another origin.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"X-Api-Key",
+	"Baggage",
+}
+
+// CheckRedirect returns the http.Client CheckRedirect function that re-injects
+// the trace context from the request context into redirected requests (instead
+// of the stale headers copied from the original request), and removes sensitive
+// headers (credentials, cookies, baggage, plus extraSensitive) when the request
+// is redirected to another origin.
+//
+// next is called first, if it's nil the http.Client default policy (stop after
+// 10 redirects) is used.
+func CheckRedirect(
+	next func(req *http.Request, via []*http.Request) error, extraSensitive ...string,
+) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if next != nil {
+			if err := next(req, via); err != nil {
+				return err
+			}
+		} else if len(via) >= maxRedirects {
+			return errors.New("stopped after 10 redirects")
+		}
+
+		InjectCarrier(req.Context(), propagation.HeaderCarrier(req.Header))
+
+		if len(via) > 0 && !sameOrigin(req, via[0]) {
+			for _, header := range sensitiveHeaders {
+				req.Header.Del(header)
+			}
+			for _, header := range extraSensitive {
+				req.Header.Del(header)
+			}
+		}
+
+		return nil
+	}
+}
+
+func sameOrigin(a, b *http.Request) bool {
+	return a.URL.Scheme == b.URL.Scheme && a.URL.Host == b.URL.Host
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCheckRedirect(t *testing.T) {
+	initTestTracer(t)
+	ctx, span := StartSpan(context.Background(), "request")
+	defer span.End()
+
+	newRequest := func(url string) *http.Request {
+		r, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	errStop := errors.New("stop")
+
+	tests := []struct {
+		name    string
+		next    func(*http.Request, []*http.Request) error
+		url     string
+		via     int
+		kept    bool // sensitive headers
+		wantErr error
+	}{
+		{"same origin", nil, "http://example.com/b", 1, true, nil},
+		{"other origin", nil, "http://other.example.com/b", 1, false, nil},
+		{"other scheme", nil, "https://example.com/b", 1, false, nil},
+		{"too many redirects", nil, "http://example.com/b", 10, true, nil},
+		{"next error", func(*http.Request, []*http.Request) error { return errStop }, "http://example.com/b", 1, true, errStop},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			via := make([]*http.Request, tt.via)
+			for i := range via {
+				via[i] = newRequest("http://example.com/a")
+			}
+			req := newRequest(tt.url)
+			// Headers copied by http.Client from the original request.
+			req.Header.Set("traceparent", "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01")
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("X-Tenant-Secret", "secret")
+
+			err := CheckRedirect(tt.next, "X-Tenant-Secret")(req, via)
+			if tt.via >= maxRedirects {
+				if err == nil {
+					t.Fatal("err = nil, want redirects limit error")
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if tp := req.Header.Get("traceparent"); !strings.Contains(tp, span.SpanId()) {
+				t.Errorf("traceparent = %q, want span %s", tp, span.SpanId())
+			}
+			for _, header := range []string{"Authorization", "X-Tenant-Secret"} {
+				if kept := req.Header.Get(header) != ""; kept != tt.kept {
+					t.Errorf("%s kept = %v, want %v", header, kept, tt.kept)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (s *span) SetHTTPStatus(code int) {
+	s.s.SetAttributes(semconv.HTTPResponseStatusCode(code))
+	if httpStatusIsError(code, s.kind()) {
+		s.s.SetStatus(codes.Error, "")
+	}
+}
+
+// httpStatusIsError implements the semconv HTTP span status rules: 5xx is an
+// error, 4xx is an error for all spans but server ones, and codes out of the
+// valid range are errors.
+func httpStatusIsError(code int, kind trace.SpanKind) bool {
+	switch {
+	case code < 100 || code >= 600: //nolint:mnd
+		return true
+	case code >= http.StatusInternalServerError:
+		return true
+	case code >= http.StatusBadRequest:
+		return kind != trace.SpanKindServer
+	default:
+		return false
+	}
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSetHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   trace.SpanKind
+		code   int
+		status codes.Code
+	}{
+		{"server ok", trace.SpanKindServer, 200, codes.Unset},
+		{"server redirect", trace.SpanKindServer, 302, codes.Unset},
+		{"server not found", trace.SpanKindServer, 404, codes.Unset},
+		{"server error", trace.SpanKindServer, 503, codes.Error},
+		{"client ok", trace.SpanKindClient, 204, codes.Unset},
+		{"client not found", trace.SpanKindClient, 404, codes.Error},
+		{"client error", trace.SpanKindClient, 500, codes.Error},
+		{"internal not found", trace.SpanKindInternal, 404, codes.Error},
+		{"invalid low", trace.SpanKindServer, 99, codes.Error},
+		{"invalid high", trace.SpanKindServer, 600, codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t)
+
+			_, span := StartSpan(context.Background(), "request", trace.WithSpanKind(tt.kind))
+			span.SetHTTPStatus(tt.code)
+			span.End()
+
+			s := recorder.Ended()[0]
+			if s.Status().Code != tt.status {
+				t.Errorf("status = %v, want %v", s.Status().Code, tt.status)
+			}
+			if v, _ := spanAttribute(s, semconv.HTTPResponseStatusCodeKey); v.AsInt64() != int64(tt.code) {
+				t.Errorf("%s = %d, want %d", semconv.HTTPResponseStatusCodeKey, v.AsInt64(), tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+)
+
+func TestPatternRoute(t *testing.T) {
+	tests := []struct {
+		pattern string
+		route   string
+	}{
+		{"", ""},
+		{"/", "/"},
+		{"/x/{id}", "/x/{id}"},
+		{"GET /x/{id}", "/x/{id}"},
+		{"GET \t /x/{id}", "/x/{id}"},
+		{"example.com/x/{id}", "/x/{id}"},
+		{"GET example.com/x/{id}", "/x/{id}"},
+		{"example.com/", "/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			if route := patternRoute(tt.pattern); route != tt.route {
+				t.Errorf("patternRoute(%q) = %q, want %q", tt.pattern, route, tt.route)
+			}
+		})
+	}
+}
+
+func TestMiddlewareResponseWriter(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		status  codes.Code
+		code    int64 // recorded status code, none if 0
+	}{
+		{"flush", func(w http.ResponseWriter, _ *http.Request) {
+			w.(http.Flusher).Flush()
+		}, codes.Unset, 200},
+		{"flush after error", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			w.(http.Flusher).Flush()
+		}, codes.Error, 502},
+		{"hijack", func(w http.ResponseWriter, _ *http.Request) {
+			conn, rw, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				panic(err)
+			}
+			defer conn.Close()
+			_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: test\r\n\r\n")
+			_ = rw.Flush()
+		}, codes.Unset, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t)
+			server := httptest.NewServer(Middleware(tt.handler))
+			defer server.Close()
+
+			resp, err := http.Get(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = resp.Body.Close()
+			server.Close()
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("ended %d spans, want 1", len(spans))
+			}
+			if status := spans[0].Status().Code; status != tt.status {
+				t.Errorf("status = %v, want %v", status, tt.status)
+			}
+			var code int64
+			for _, kv := range spans[0].Attributes() {
+				if kv.Key == semconv.HTTPResponseStatusCodeKey {
+					code = kv.Value.AsInt64()
+				}
+			}
+			if code != tt.code {
+				t.Errorf("status code = %d, want %d", code, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"go.opentelemetry.io/otel/baggage"
+
+	"github.com/cdnnow-pro/go-tracer/keys"
+)
+
+// IdentityMode is how SetRequestIdentity records an identity.
+type IdentityMode int
+
+const (
+	// IdentityPlain records the ID as is.
+	IdentityPlain IdentityMode = iota
+	// IdentityHashed records the hex HMAC-SHA256 of the ID, truncated to 16
+	// bytes. Services configured with the same key record the same value.
+	IdentityHashed
+	// IdentityRedacted doesn't record the ID.
+	IdentityRedacted
+)
+
+// identityHashLen is the length of hashed IDs in bytes.
+const identityHashLen = 16
+
+// WithRequestIdentity sets how SetRequestIdentity records user and tenant IDs,
+// and the key of IdentityHashed (may be nil). By default, IDs are recorded as is.
+func WithRequestIdentity(user, tenant IdentityMode, key []byte) Option {
+	return func(opts *Options) {
+		opts.identity = identityPolicy{user: user, tenant: tenant, key: key}
+	}
+}
+
+type identityPolicy struct {
+	user   IdentityMode
+	tenant IdentityMode
+	key    []byte
+}
+
+// SetRequestIdentity tags the local root span of ctx with the user, tenant and
+// request IDs, and returns the context carrying them as baggage members for
+// downstream services. User and tenant IDs are recorded as set by
+// WithRequestIdentity, the request ID is recorded as is. Empty IDs are skipped.
+func SetRequestIdentity(ctx context.Context, userID, tenantID, requestID string) context.Context {
+	root := localRootFromContext(ctx)
+	policy := root.t.options.identity
+
+	bag := baggage.FromContext(ctx)
+	for _, id := range []struct {
+		key   keys.StringKey
+		value string
+		mode  IdentityMode
+	}{
+		{keys.UserID, userID, policy.user},
+		{keys.TenantID, tenantID, policy.tenant},
+		{keys.RequestID, requestID, IdentityPlain},
+	} {
+		if id.value == "" || id.mode == IdentityRedacted {
+			continue
+		}
+
+		value := id.value
+		if id.mode == IdentityHashed {
+			value = hashIdentity(policy.key, value)
+		}
+		id.key.Set(root, value)
+
+		member, err := baggage.NewMemberRaw(id.key.Name(), value)
+		if err != nil {
+			continue
+		}
+		if b, err := bag.SetMember(member); err == nil {
+			bag = b
+		}
+	}
+
+	return baggage.ContextWithBaggage(ctx, bag)
+}
+
+func hashIdentity(key []byte, id string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil)[:identityHashLen])
+}
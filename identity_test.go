@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cdnnow-pro/go-tracer/keys"
+)
+
+func TestSetRequestIdentity(t *testing.T) {
+	key := []byte("secret")
+
+	tests := []struct {
+		name                 string
+		opts                 []Option
+		userID, tenantID     string
+		wantUser, wantTenant string // recorded values, none if empty
+	}{
+		{"plain", nil, "u1", "acme", "u1", "acme"},
+		{"hashed user", []Option{WithRequestIdentity(IdentityHashed, IdentityPlain, key)}, "u1", "acme",
+			hashIdentity(key, "u1"), "acme"},
+		{"redacted tenant", []Option{WithRequestIdentity(IdentityPlain, IdentityRedacted, nil)}, "u1", "acme", "u1", ""},
+		{"empty IDs", nil, "", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t, tt.opts...)
+
+			ctx, root := StartSpan(context.Background(), "root")
+			ctx, child := StartSpan(ctx, "child")
+			ctx = SetRequestIdentity(ctx, tt.userID, tt.tenantID, "req-1")
+			child.End()
+			root.End()
+
+			for _, id := range []struct {
+				key  keys.StringKey
+				want string
+			}{
+				{keys.UserID, tt.wantUser},
+				{keys.TenantID, tt.wantTenant},
+				{keys.RequestID, "req-1"},
+			} {
+				if got := Baggage(ctx, id.key.Name()); got != id.want {
+					t.Errorf("baggage %s = %q, want %q", id.key, got, id.want)
+				}
+				if v, _ := spanAttribute(recorder.Ended()[1], id.key.Key()); v.AsString() != id.want {
+					t.Errorf("root %s = %q, want %q", id.key, v.AsString(), id.want)
+				}
+			}
+		})
+	}
+}
+
+func TestHashIdentity(t *testing.T) {
+	hashed := hashIdentity([]byte("k"), "u1")
+	if len(hashed) != 2*identityHashLen {
+		t.Errorf("hash length = %d, want %d", len(hashed), 2*identityHashLen)
+	}
+	if hashIdentity([]byte("k"), "u1") != hashed {
+		t.Error("hash isn't deterministic")
+	}
+	if hashIdentity([]byte("other"), "u1") == hashed {
+		t.Error("hash doesn't depend on the key")
+	}
+}
@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/cdnnow-pro/go-tracer/keys"
+)
+
+// AnnotateIncident tags the local root span of ctx with the incident ID and
+// returns the context carrying it as the "incident.id" baggage member, so spans
+// started with the returned context (here and in downstream services accepting
+// baggage) are tagged with it too. Useful when reproducing customer-reported
+// issues.
+func AnnotateIncident(ctx context.Context, incidentID string) context.Context {
+	keys.IncidentID.Set(localRootFromContext(ctx), incidentID)
+	return SetBaggage(ctx, keys.IncidentID.Name(), incidentID)
+}
+
+// incidentAttributes returns attributes of the span started with ctx.
+func incidentAttributes(ctx context.Context) []attribute.KeyValue {
+	incidentID := Baggage(ctx, keys.IncidentID.Name())
+	if incidentID == "" {
+		return nil
+	}
+	return []attribute.KeyValue{keys.IncidentID.Attr(incidentID)}
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cdnnow-pro/go-tracer/keys"
+)
+
+func TestAnnotateIncident(t *testing.T) {
+	recorder := initTestTracer(t)
+
+	ctx, root := StartSpan(context.Background(), "root")
+	ctx, handler := StartSpan(ctx, "handler")
+	ctx = AnnotateIncident(ctx, "INC-42")
+	_, downstream := StartSpan(ctx, "downstream")
+	downstream.End()
+	handler.End()
+	root.End()
+
+	if got := Baggage(ctx, keys.IncidentID.Name()); got != "INC-42" {
+		t.Errorf("baggage incident.id = %q, want INC-42", got)
+	}
+	want := map[string]string{"downstream": "INC-42", "handler": "", "root": "INC-42"}
+	for _, s := range recorder.Ended() {
+		if v, _ := spanAttribute(s, keys.IncidentID.Key()); v.AsString() != want[s.Name()] {
+			t.Errorf("%s: incident.id = %q, want %q", s.Name(), v.AsString(), want[s.Name()])
+		}
+	}
+}
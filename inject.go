@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Inject writes the trace context and baggage of ctx to the HTTP headers, in the
+// formats of the default tracer (see WithPropagators).
+func Inject(ctx context.Context, header http.Header) {
+	current().InjectCarrier(ctx, propagation.HeaderCarrier(header))
+}
+
+// Extract returns ctx with the trace context and baggage read from the HTTP
+// headers, in the formats of the default tracer (see WithPropagators).
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return current().ExtractCarrier(ctx, propagation.HeaderCarrier(header))
+}
+
+// InjectCarrier writes the trace context and baggage of ctx to the carrier, e.g.
+// message headers, with the default tracer.
+func InjectCarrier(ctx context.Context, carrier propagation.TextMapCarrier) {
+	current().InjectCarrier(ctx, carrier)
+}
+
+// ExtractCarrier returns ctx with the trace context and baggage read from the
+// carrier with the default tracer.
+func ExtractCarrier(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return current().ExtractCarrier(ctx, carrier)
+}
+
+// InjectCarrier writes the trace context and baggage of ctx to the carrier, in
+// the formats of the tracer.
+func (t *Tracer) InjectCarrier(ctx context.Context, carrier propagation.TextMapCarrier) {
+	t.propagator.Inject(ctx, carrier)
+}
+
+// ExtractCarrier returns ctx with the trace context and baggage read from the
+// carrier, in the formats of the tracer.
+func (t *Tracer) ExtractCarrier(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return t.propagator.Extract(ctx, carrier)
+}
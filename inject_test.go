@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInjectExtract(t *testing.T) {
+	initTestTracer(t, WithPropagators(B3MultiPropagator()))
+
+	ctx, span := StartSpan(SetBaggage(context.Background(), "tenant", "acme"), "producer")
+	defer span.End()
+	sc := trace.SpanContextFromContext(ctx)
+
+	header := http.Header{}
+	Inject(ctx, header)
+	if header.Get("traceparent") == "" || header.Get("X-B3-TraceId") != sc.TraceID().String() {
+		t.Errorf("header = %v, want W3C and B3 trace context", header)
+	}
+	if got := Baggage(Extract(context.Background(), header), "tenant"); got != "acme" {
+		t.Errorf("extracted baggage tenant = %q, want acme", got)
+	}
+
+	carrier := propagation.MapCarrier{}
+	InjectCarrier(ctx, carrier)
+	extracted := trace.SpanContextFromContext(ExtractCarrier(context.Background(), carrier))
+	if !extracted.IsRemote() || extracted.TraceID() != sc.TraceID() || extracted.SpanID() != sc.SpanID() {
+		t.Errorf("extracted span context = %v, want remote %v", extracted, sc)
+	}
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Instrumentation starts spans for an instrumented library. Libraries accept it
+// (e.g. in their options) instead of depending on OpenTelemetry directly, so
+// their spans follow the conventions of this package (error handling at
+// Span.End, status helpers, span processors) and go to the application tracer.
+type Instrumentation interface {
+	StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, Span)
+}
+
+// WrapTracer returns the Instrumentation of the library (its import path) with
+// the version, starting spans with the tracer t under the instrumentation scope
+// of the library. If t is nil, spans are started with the default tracer at the
+// time of the call, so libraries can be set up before Init.
+func WrapTracer(t *Tracer, library, version string) Instrumentation {
+	return instrumentation{t: t, library: library, version: version}
+}
+
+type instrumentation struct {
+	t       *Tracer
+	library string
+	version string
+}
+
+func (i instrumentation) StartSpan(
+	ctx context.Context, name string, opts ...trace.SpanStartOption,
+) (context.Context, Span) {
+	t := i.t
+	if t == nil {
+		t = current()
+	}
+
+	tracer := t.tracer
+	if t.provider != nil {
+		tracer = t.provider.Tracer(i.library, trace.WithInstrumentationVersion(i.version))
+	}
+	return t.startSpan(ctx, tracer, name, opts...)
+}
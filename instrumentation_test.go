@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWrapTracer(t *testing.T) {
+	recorder := initTestTracer(t)
+	// The default tracer is resolved when the span starts.
+	lib := WrapTracer(nil, "example.com/lib", "v1.2.3")
+
+	ctx, parent := StartSpan(context.Background(), "app")
+	_, span := lib.StartSpan(ctx, "lib.Call")
+	err := errors.New("lib failed")
+	span.End(&err)
+	parent.End()
+
+	s := recorder.Ended()[0]
+	if scope := s.InstrumentationScope(); scope.Name != "example.com/lib" || scope.Version != "v1.2.3" {
+		t.Errorf("scope = %s %s, want example.com/lib v1.2.3", scope.Name, scope.Version)
+	}
+	if s.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("library span isn't the child of the application span")
+	}
+	if s.Status().Code != codes.Error {
+		t.Errorf("status = %v, want Error from End", s.Status().Code)
+	}
+}
+
+func TestWrapTracerInstance(t *testing.T) {
+	defaultRecorder := initTestTracer(t)
+	recorder := tracetest.NewSpanRecorder()
+	tr, err := New(context.Background(), "other", "1.0.0",
+		WithExporter(tracetest.NewNoopExporter()), WithSpanProcessor(recorder), WithAlwaysSample())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = tr.Shutdown(context.Background()) })
+
+	_, span := WrapTracer(tr, "example.com/lib", "v1").StartSpan(context.Background(), "lib.Call")
+	span.End()
+
+	if spans := recorder.Ended(); len(spans) != 1 || spans[0].InstrumentationScope().Name != "example.com/lib" {
+		t.Errorf("the tracer instance recorded %d spans, want the library span", len(spans))
+	}
+	if n := len(defaultRecorder.Ended()); n != 0 {
+		t.Errorf("the default tracer recorded %d spans, want 0", n)
+	}
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+
+// Package kafkatracer propagates the trace context through Kafka record headers,
+// so traces continue across the asynchronous hop. It works with any client
+// library: headers of segmentio/kafka-go (and other libraries with string header
+// keys) are adapted by HeaderCarrier, others by a propagation.TextMapCarrier of
+// their own.
+//
+// The producer starts the span before sending the message, which injects the
+// trace context into the headers:
+//
+//	ctx, span := kafkatracer.StartProduceSpan(ctx, msg.Topic, kafkatracer.HeaderCarrier(&msg.Headers))
+//	err = w.WriteMessages(ctx, msg)
+//	span.End(&err)
+//
+// The consumer starts the span processing the message, which continues the
+// producer's trace:
+//
+//	ctx, span := kafkatracer.StartConsumeSpan(ctx, msg.Topic, kafkatracer.HeaderCarrier(&msg.Headers))
+//	err = handle(ctx, msg)
+//	span.End(&err)
+package kafkatracer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+)
+
+// Header is the record header type with string keys, e.g. kafka.Header of
+// segmentio/kafka-go.
+type Header interface {
+	~struct {
+		Key   string
+		Value []byte
+	}
+}
+
+// HeaderCarrier returns the carrier of the trace context in the record headers.
+func HeaderCarrier[H Header](headers *[]H) propagation.TextMapCarrier {
+	return headerCarrier[H]{headers: headers}
+}
+
+type headerCarrier[H Header] struct {
+	headers *[]H
+}
+
+type header = struct {
+	Key   string
+	Value []byte
+}
+
+func (c headerCarrier[H]) Get(key string) string {
+	for _, h := range *c.headers {
+		if h := header(h); h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c headerCarrier[H]) Set(key, value string) {
+	for i, h := range *c.headers {
+		if header(h).Key == key {
+			(*c.headers)[i] = H(header{Key: key, Value: []byte(value)})
+			return
+		}
+	}
+	*c.headers = append(*c.headers, H(header{Key: key, Value: []byte(value)}))
+}
+
+func (c headerCarrier[H]) Keys() []string {
+	keys := make([]string, len(*c.headers))
+	for i, h := range *c.headers {
+		keys[i] = header(h).Key
+	}
+	return keys
+}
+
+// StartProduceSpan starts the producer span "kafka.produce" of sending the
+// message to the topic and injects its context into carrier (the message
+// headers). opts can add e.g. the message key attribute.
+func StartProduceSpan(
+	ctx context.Context, topic string, carrier propagation.TextMapCarrier, opts ...trace.SpanStartOption,
+) (context.Context, tracer.Span) {
+	opts = append(opts, trace.WithAttributes(
+		semconv.MessagingSystemKafka,
+		semconv.MessagingOperationTypePublish,
+		semconv.MessagingDestinationName(topic),
+	))
+	ctx, span := tracer.StartProducerSpan(ctx, "kafka.produce", opts...)
+	tracer.InjectCarrier(ctx, carrier)
+	return ctx, span
+}
+
+// StartConsumeSpan starts the consumer span "kafka.consume" of processing the
+// message consumed from the topic, the child of the producer span extracted from
+// carrier (the message headers), or of the span of ctx if the message has no
+// trace context. opts can add e.g. the partition and offset attributes.
+func StartConsumeSpan(
+	ctx context.Context, topic string, carrier propagation.TextMapCarrier, opts ...trace.SpanStartOption,
+) (context.Context, tracer.Span) {
+	opts = append(opts, trace.WithAttributes(
+		semconv.MessagingSystemKafka,
+		semconv.MessagingOperationTypeProcess,
+		semconv.MessagingDestinationName(topic),
+	))
+	return tracer.StartConsumerSpan(tracer.ExtractCarrier(ctx, carrier), "kafka.consume", opts...)
+}
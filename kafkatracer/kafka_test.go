@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: MIT
+
+package kafkatracer_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+	"github.com/cdnnow-pro/go-tracer/kafkatracer"
+	"github.com/cdnnow-pro/go-tracer/tracertest"
+)
+
+// header is the record header of kafka-go.
+type header struct {
+	Key   string
+	Value []byte
+}
+
+func TestHeaderCarrier(t *testing.T) {
+	headers := []header{{Key: "content-type", Value: []byte("json")}}
+	carrier := kafkatracer.HeaderCarrier(&headers)
+
+	carrier.Set("traceparent", "a")
+	carrier.Set("traceparent", "b")
+	if got := carrier.Get("traceparent"); got != "b" {
+		t.Errorf("Get(traceparent) = %q, want b", got)
+	}
+	if got := carrier.Get("missing"); got != "" {
+		t.Errorf("Get(missing) = %q, want empty", got)
+	}
+	if keys := carrier.Keys(); !slices.Equal(keys, []string{"content-type", "traceparent"}) {
+		t.Errorf("Keys() = %v, want [content-type traceparent]", keys)
+	}
+	if len(headers) != 2 || string(headers[0].Value) != "json" {
+		t.Errorf("headers = %v, want the existing header kept and traceparent set once", headers)
+	}
+}
+
+func TestProduceConsume(t *testing.T) {
+	tracertest.Init(t)
+
+	var headers []header
+	ctx, produce := kafkatracer.StartProduceSpan(context.Background(), "orders", kafkatracer.HeaderCarrier(&headers))
+	produce.End()
+
+	_, consume := kafkatracer.StartConsumeSpan(context.Background(), "orders", kafkatracer.HeaderCarrier(&headers))
+	consume.End()
+
+	tracertest.AssertSpan(t, "kafka.produce").WithTag("messaging.system", "kafka").
+		WithTag("messaging.operation.type", "publish").WithTag("messaging.destination.name", "orders")
+	tracertest.AssertSpan(t, "kafka.consume").WithTag("messaging.operation.type", "process").
+		WithTag("messaging.destination.name", "orders")
+
+	spans := tracertest.Spans(t)
+	if len(spans) != 2 {
+		t.Fatalf("ended %d spans, want 2", len(spans))
+	}
+	producer, consumer := spans[0], spans[1]
+	if producer.SpanKind() != trace.SpanKindProducer || consumer.SpanKind() != trace.SpanKindConsumer {
+		t.Errorf("span kinds = %v, %v, want producer, consumer", producer.SpanKind(), consumer.SpanKind())
+	}
+	if consumer.Parent().SpanID() != producer.SpanContext().SpanID() || !consumer.Parent().IsRemote() {
+		t.Errorf("consumer parent = %v, want the remote producer span", consumer.Parent())
+	}
+	if trace.SpanContextFromContext(ctx).SpanID() != producer.SpanContext().SpanID() {
+		t.Error("produce context doesn't carry the producer span")
+	}
+}
+
+func TestConsumeWithoutTraceContext(t *testing.T) {
+	tracertest.Init(t)
+
+	ctx, parent := tracer.StartSpan(context.Background(), "poll")
+	_, consume := kafkatracer.StartConsumeSpan(ctx, "orders", kafkatracer.HeaderCarrier(&[]header{}))
+	consume.End()
+	parent.End()
+
+	if consumer := tracertest.Spans(t)[0]; consumer.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("consumer parent = %v, want the span of ctx", consumer.Parent())
+	}
+}
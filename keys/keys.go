@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: MIT
+
+// Package keys contains attribute keys standardized across our services.
+//
+// Keys are typed by the value type, so the same key can't be set with values of
+// different types by different teams:
+//
+//	keys.TenantID.Set(span, tenantID)
+//	span.Tag(keys.CacheHit.Name(), true) // the same as keys.CacheHit.Set(span, true)
+package keys
+
+import "go.opentelemetry.io/otel/attribute"
+
+// Tagger is the span the attribute is set on (tracer.Span).
+type Tagger interface {
+	Tag(key string, value any)
+}
+
+// Identity and correlation.
+const (
+	TenantID   StringKey = "tenant.id"
+	UserID     StringKey = "enduser.id"
+	RequestID  StringKey = "request.id"
+	IncidentID StringKey = "incident.id"
+	Component  StringKey = "component"
+)
+
+// Deployment.
+const (
+	Environment StringKey = "deployment.environment"
+	Region      StringKey = "cloud.region"
+	Cluster     StringKey = "k8s.cluster.name"
+)
+
+// CDN edge.
+const (
+	PoP          StringKey = "cdn.pop"
+	CacheStatus  StringKey = "cdn.cache.status"
+	CacheHit     BoolKey   = "cdn.cache.hit"
+	Origin       StringKey = "cdn.origin"
+	UpstreamTime Int64Key  = "cdn.upstream.duration_ms"
+	ContentBytes Int64Key  = "cdn.content.bytes"
+)
+
+// Export.
+const (
+	// Critical routes the span through the critical lane (see tracer.WithCriticalLane).
+	Critical BoolKey = "span.critical"
+)
+
+// Security.
+const (
+	// Audit routes the span to the audit trail (see tracer.WithAuditTrail).
+	Audit BoolKey = "security.audit"
+)
+
+// Work accounting.
+const (
+	RowsAffected Int64Key = "db.rows_affected"
+	Retries      IntKey   = "retry.count"
+	BatchSize    IntKey   = "batch.size"
+)
+
+// StringKey is the key of a string attribute.
+type StringKey string
+
+func (k StringKey) Key() attribute.Key                  { return attribute.Key(k) }
+func (k StringKey) Name() string                        { return string(k) }
+func (k StringKey) Attr(v string) attribute.KeyValue    { return attribute.String(string(k), v) }
+func (k StringKey) Set(s Tagger, v string)              { s.Tag(string(k), v) }
+func (k StringKey) Slice(v []string) attribute.KeyValue { return attribute.StringSlice(string(k), v) }
+
+// IntKey is the key of an int attribute.
+type IntKey string
+
+func (k IntKey) Key() attribute.Key            { return attribute.Key(k) }
+func (k IntKey) Name() string                  { return string(k) }
+func (k IntKey) Attr(v int) attribute.KeyValue { return attribute.Int(string(k), v) }
+func (k IntKey) Set(s Tagger, v int)           { s.Tag(string(k), v) }
+
+// Int64Key is the key of an int64 attribute.
+type Int64Key string
+
+func (k Int64Key) Key() attribute.Key              { return attribute.Key(k) }
+func (k Int64Key) Name() string                    { return string(k) }
+func (k Int64Key) Attr(v int64) attribute.KeyValue { return attribute.Int64(string(k), v) }
+func (k Int64Key) Set(s Tagger, v int64)           { s.Tag(string(k), v) }
+
+// Float64Key is the key of a float64 attribute.
+type Float64Key string
+
+func (k Float64Key) Key() attribute.Key                { return attribute.Key(k) }
+func (k Float64Key) Name() string                      { return string(k) }
+func (k Float64Key) Attr(v float64) attribute.KeyValue { return attribute.Float64(string(k), v) }
+func (k Float64Key) Set(s Tagger, v float64)           { s.Tag(string(k), v) }
+
+// BoolKey is the key of a bool attribute.
+type BoolKey string
+
+func (k BoolKey) Key() attribute.Key             { return attribute.Key(k) }
+func (k BoolKey) Name() string                   { return string(k) }
+func (k BoolKey) Attr(v bool) attribute.KeyValue { return attribute.Bool(string(k), v) }
+func (k BoolKey) Set(s Tagger, v bool)           { s.Tag(string(k), v) }
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+
+package keys_test
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/cdnnow-pro/go-tracer/keys"
+)
+
+// tags records the set tags.
+type tags map[string]any
+
+func (t tags) Tag(key string, value any) {
+	t[key] = value
+}
+
+func TestSet(t *testing.T) {
+	got := tags{}
+	keys.TenantID.Set(got, "acme")
+	keys.CacheHit.Set(got, true)
+	keys.Retries.Set(got, 2)
+	keys.UpstreamTime.Set(got, int64(15))
+	keys.Float64Key("load").Set(got, 0.5)
+
+	want := tags{
+		"tenant.id":                "acme",
+		"cdn.cache.hit":            true,
+		"retry.count":              2,
+		"cdn.upstream.duration_ms": int64(15),
+		"load":                     0.5,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("tags = %v, want %v", got, want)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("tag %s = %#v, want %#v", key, got[key], value)
+		}
+	}
+}
+
+func TestAttr(t *testing.T) {
+	tests := []struct {
+		got  attribute.KeyValue
+		want attribute.KeyValue
+	}{
+		{keys.TenantID.Attr("acme"), attribute.String("tenant.id", "acme")},
+		{keys.PoP.Slice([]string{"ams", "fra"}), attribute.StringSlice("cdn.pop", []string{"ams", "fra"})},
+		{keys.BatchSize.Attr(10), attribute.Int("batch.size", 10)},
+		{keys.ContentBytes.Attr(1024), attribute.Int64("cdn.content.bytes", 1024)},
+		{keys.Float64Key("load").Attr(0.5), attribute.Float64("load", 0.5)},
+		{keys.CacheHit.Attr(false), attribute.Bool("cdn.cache.hit", false)},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("attribute = %v, want %v", tt.got, tt.want)
+		}
+	}
+	if keys.UserID.Key() != "enduser.id" || keys.UserID.Name() != "enduser.id" {
+		t.Errorf("UserID key = %q, name = %q, want enduser.id", keys.UserID.Key(), keys.UserID.Name())
+	}
+}
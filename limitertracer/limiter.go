@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+
+// Package limitertracer records rate limiter throttling on spans, so it doesn't
+// look like unexplained latency. It works with golang.org/x/time/rate limiters
+// and any other limiter with the same methods.
+package limitertracer
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+)
+
+// minRecordedWait is the shortest wait recorded as the event.
+const minRecordedWait = time.Millisecond
+
+// Waiter is the limiter blocking until the request is allowed, e.g.
+// *rate.Limiter.
+type Waiter interface {
+	Wait(ctx context.Context) error
+}
+
+// Allower is the limiter rejecting the request if it isn't allowed, e.g.
+// *rate.Limiter.
+type Allower interface {
+	Allow() bool
+}
+
+// Wait waits for the limiter named name. Waits of at least 1ms are recorded on
+// the span of ctx as the "rate_limiter.wait" event with the wait duration
+// ("rate_limiter.wait_ms"), failed waits (the wait would exceed the ctx deadline,
+// or ctx is done) as the "rate_limiter.rejected" event.
+func Wait(ctx context.Context, name string, limiter Waiter) error {
+	start := time.Now()
+	err := limiter.Wait(ctx)
+	waited := time.Since(start)
+
+	span := tracer.SpanFromContext(ctx)
+	attrs := []attribute.KeyValue{
+		attribute.String("rate_limiter.name", name),
+		attribute.Int64("rate_limiter.wait_ms", waited.Milliseconds()),
+	}
+	switch {
+	case err != nil:
+		span.AddEvent("rate_limiter.rejected", trace.WithAttributes(append(attrs,
+			attribute.String("rate_limiter.error", err.Error()),
+		)...))
+	case waited >= minRecordedWait:
+		span.AddEvent("rate_limiter.wait", trace.WithAttributes(attrs...))
+	}
+	return err
+}
+
+// Allow reports whether the limiter named name allows the request. Rejections
+// are recorded on the span of ctx as the "rate_limiter.rejected" event.
+func Allow(ctx context.Context, name string, limiter Allower) bool {
+	if limiter.Allow() {
+		return true
+	}
+
+	tracer.SpanFromContext(ctx).AddEvent("rate_limiter.rejected", trace.WithAttributes(
+		attribute.String("rate_limiter.name", name),
+	))
+	return false
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+
+package limitertracer_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+	"github.com/cdnnow-pro/go-tracer/limitertracer"
+	"github.com/cdnnow-pro/go-tracer/tracertest"
+)
+
+// waiter waits for wait and fails with err.
+type waiter struct {
+	wait time.Duration
+	err  error
+}
+
+func (w waiter) Wait(context.Context) error {
+	time.Sleep(w.wait)
+	return w.err
+}
+
+type allower bool
+
+func (a allower) Allow() bool { return bool(a) }
+
+func TestWait(t *testing.T) {
+	tests := []struct {
+		name   string
+		waiter waiter
+		events []string
+	}{
+		{"immediate", waiter{}, nil},
+		{"throttled", waiter{wait: 5 * time.Millisecond}, []string{"rate_limiter.wait"}},
+		{"rejected", waiter{err: errors.New("would exceed deadline")}, []string{"rate_limiter.rejected"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracertest.Init(t)
+
+			ctx, span := tracer.StartSpan(context.Background(), "request")
+			if err := limitertracer.Wait(ctx, "api", tt.waiter); !errors.Is(err, tt.waiter.err) {
+				t.Errorf("Wait() = %v, want %v", err, tt.waiter.err)
+			}
+			span.End()
+
+			s := tracertest.Spans(t)[0]
+			var events []string
+			for _, e := range s.Events() {
+				events = append(events, e.Name)
+				for _, kv := range e.Attributes {
+					if kv.Key == "rate_limiter.name" && kv.Value.AsString() != "api" {
+						t.Errorf("rate_limiter.name = %q, want api", kv.Value.AsString())
+					}
+					if kv.Key == "rate_limiter.wait_ms" && kv.Value.AsInt64() < tt.waiter.wait.Milliseconds() {
+						t.Errorf("rate_limiter.wait_ms = %d, want at least %d", kv.Value.AsInt64(), tt.waiter.wait.Milliseconds())
+					}
+				}
+			}
+			if !slices.Equal(events, tt.events) {
+				t.Errorf("events = %v, want %v", events, tt.events)
+			}
+		})
+	}
+}
+
+func TestAllow(t *testing.T) {
+	for _, allowed := range []bool{true, false} {
+		t.Run(strconv.FormatBool(allowed), func(t *testing.T) {
+			tracertest.Init(t)
+
+			ctx, span := tracer.StartSpan(context.Background(), "request")
+			if got := limitertracer.Allow(ctx, "api", allower(allowed)); got != allowed {
+				t.Errorf("Allow() = %v, want %v", got, allowed)
+			}
+			span.End()
+
+			if rejected := len(tracertest.Spans(t)[0].Events()) == 1; rejected == allowed {
+				t.Errorf("rejected event = %v, want %v", rejected, !allowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// WithStdoutExporter writes pretty-printed spans to stdout instead of exporting
+// them to the collector (for local development).
+func WithStdoutExporter() Option {
+	return func(opts *Options) {
+		opts.localExporter = &localExporter{}
+	}
+}
+
+// WithFileExporter appends spans to the file at path as JSON lines instead of
+// exporting them to the collector (for local development). The file is created
+// if it doesn't exist.
+func WithFileExporter(path string) Option {
+	return func(opts *Options) {
+		opts.localExporter = &localExporter{path: path}
+	}
+}
+
+// WithExporter exports spans with exporter instead of the collector, e.g. to an
+// in-memory exporter in tests.
+func WithExporter(exporter tracesdk.SpanExporter) Option {
+	return func(opts *Options) {
+		opts.localExporter = &localExporter{exporter: exporter}
+	}
+}
+
+// localExporter is set by WithStdoutExporter (empty path), WithFileExporter or
+// WithExporter.
+type localExporter struct {
+	path     string
+	exporter tracesdk.SpanExporter
+}
+
+func makeLocalExporter(le *localExporter) (tracesdk.SpanExporter, error) {
+	if le.exporter != nil {
+		return le.exporter, nil
+	}
+	if le.path == "" {
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create exporter: %w", err)
+		}
+		return exporter, nil
+	}
+
+	f, err := os.OpenFile(le.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644) //nolint:gosec,mnd
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spans file: %w", err)
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to create exporter: %w", err), f.Close())
+	}
+	return fileExporter{SpanExporter: exporter, f: f}, nil
+}
+
+// fileExporter closes the file on shutdown.
+type fileExporter struct {
+	tracesdk.SpanExporter
+	f *os.File
+}
+
+func (e fileExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.SpanExporter.Shutdown(ctx), e.f.Close())
+}
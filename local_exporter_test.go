@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.jsonl")
+
+	// The second tracer appends to the file.
+	for _, name := range []string{"first", "second"} {
+		tr, err := New(context.Background(), "app", "1.0.0", WithFileExporter(path), WithAlwaysSample())
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, span := tr.StartSpan(context.Background(), name)
+		span.End()
+		if err := tr.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var names []string
+	for scanner := bufio.NewScanner(f); scanner.Scan(); {
+		var s struct{ Name string }
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		names = append(names, s.Name)
+	}
+	if len(names) != 2 || names[0] != "first" || names[1] != "second" {
+		t.Errorf("spans = %v, want [first second]", names)
+	}
+}
+
+func TestFileExporterError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "spans.jsonl")
+	if _, err := New(context.Background(), "app", "1.0.0", WithFileExporter(path)); err == nil {
+		t.Error("New() with the file in a missing directory succeeded")
+	}
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"sync"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// endingSpans holds spans with local values while their processors' OnEnd run,
+// so processors can read them with SpanLocal.
+var endingSpans sync.Map // trace.SpanID -> *span
+
+type (
+	spanKey      struct{}
+	localRootKey struct{}
+)
+
+// contextWithSpan returns ctx carrying span, so SpanFromContext returns the same
+// wrapper with its local values.
+func contextWithSpan(ctx context.Context, span *span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// contextWithLocalRoot returns ctx carrying span as the local root span.
+func contextWithLocalRoot(ctx context.Context, span *span) context.Context {
+	return context.WithValue(ctx, localRootKey{}, span)
+}
+
+// localRootFromContext returns the local root span of ctx, or the current span
+// if the local root is unknown (e.g. started by other instrumentation).
+func localRootFromContext(ctx context.Context) *span {
+	if root, ok := ctx.Value(localRootKey{}).(*span); ok {
+		return root
+	}
+	return SpanFromContext(ctx)
+}
+
+// spanFromContext returns the wrapper stored in ctx if it wraps the current span
+// of ctx.
+func spanFromContext(ctx context.Context) (*span, bool) {
+	span, ok := ctx.Value(spanKey{}).(*span)
+	if !ok || !span.s.SpanContext().Equal(trace.SpanContextFromContext(ctx)) {
+		return nil, false
+	}
+	return span, true
+}
+
+func (s *span) SetLocal(key, value any) {
+	s.localsMu.Lock()
+	defer s.localsMu.Unlock()
+
+	if s.locals == nil {
+		s.locals = make(map[any]any)
+	}
+	s.locals[key] = value
+}
+
+func (s *span) GetLocal(key any) (any, bool) {
+	s.localsMu.Lock()
+	defer s.localsMu.Unlock()
+
+	value, ok := s.locals[key]
+	return value, ok
+}
+
+func (s *span) hasLocals() bool {
+	s.localsMu.Lock()
+	defer s.localsMu.Unlock()
+
+	return len(s.locals) > 0
+}
+
+// SpanLocal returns the local value (see Span.SetLocal) of the span being ended.
+// It's intended for span processors, and works only within their OnEnd.
+func SpanLocal(s tracesdk.ReadOnlySpan, key any) (any, bool) {
+	ending, ok := endingSpans.Load(s.SpanContext().SpanID())
+	if !ok {
+		return nil, false
+	}
+	return ending.(*span).GetLocal(key) //nolint:forcetypeassert
+}
+
+// endWithLocals ends the span making its local values available to processors.
+func (s *span) endWithLocals() {
+	if !s.hasLocals() || !s.s.SpanContext().IsValid() {
+		s.s.End()
+		return
+	}
+
+	id := s.s.SpanContext().SpanID()
+	endingSpans.Store(id, s)
+	s.s.End()
+	endingSpans.Delete(id)
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type routeKey struct{}
+
+// localsRecorder records the route local of ended spans.
+type localsRecorder struct {
+	tracesdk.SpanProcessor
+
+	routes map[string]any
+}
+
+func (r *localsRecorder) OnEnd(s tracesdk.ReadOnlySpan) {
+	if route, ok := SpanLocal(s, routeKey{}); ok {
+		r.routes[s.Name()] = route
+	}
+}
+
+func TestSpanLocals(t *testing.T) {
+	recorder := &localsRecorder{SpanProcessor: tracetest.NewSpanRecorder(), routes: make(map[string]any)}
+	ended := initTestTracer(t, WithSpanProcessor(recorder))
+
+	ctx, span := StartSpan(context.Background(), "request")
+	span.SetLocal(routeKey{}, "/x/{id}")
+	if route, ok := SpanFromContext(ctx).GetLocal(routeKey{}); !ok || route != "/x/{id}" {
+		t.Errorf("GetLocal() from context = %v, %v, want /x/{id}", route, ok)
+	}
+	if _, ok := span.GetLocal("other"); ok {
+		t.Error("GetLocal() of unset key found a value")
+	}
+
+	_, other := StartSpan(ctx, "other")
+	other.End()
+	span.End()
+
+	if route := recorder.routes["request"]; route != "/x/{id}" {
+		t.Errorf("SpanLocal() in OnEnd = %v, want /x/{id}", route)
+	}
+	if _, ok := recorder.routes["other"]; ok {
+		t.Error("SpanLocal() of the span without locals found a value")
+	}
+	if _, ok := SpanLocal(ended.Ended()[1], routeKey{}); ok {
+		t.Error("SpanLocal() works after OnEnd")
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LogFields returns the "trace_id" and "span_id" log attributes of the span of
+// ctx, or nil if ctx has no sampled span:
+//
+//	logger.LogAttrs(ctx, slog.LevelInfo, "done", tracer.LogFields(ctx)...)
+//
+// For zap loggers, see zaptracer.Fields.
+func LogFields(ctx context.Context) []slog.Attr {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() || !sc.IsSampled() {
+		return nil
+	}
+	return []slog.Attr{
+		slog.String("trace_id", sc.TraceID().String()),
+		slog.String("span_id", sc.SpanID().String()),
+	}
+}
+
+// CorrelatedLogHandler wraps the slog handler, so records logged with a context
+// of a sampled span carry its trace and span IDs (see LogFields).
+func CorrelatedLogHandler(next slog.Handler) slog.Handler {
+	return correlationHandler{next: next}
+}
+
+type correlationHandler struct {
+	next slog.Handler
+}
+
+func (h correlationHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+//nolint:gocritic // slog.Handler signature.
+func (h correlationHandler) Handle(ctx context.Context, r slog.Record) error {
+	if attrs := LogFields(ctx); attrs != nil {
+		r.AddAttrs(attrs...)
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h correlationHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return correlationHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h correlationHandler) WithGroup(name string) slog.Handler {
+	return correlationHandler{next: h.next.WithGroup(name)}
+}
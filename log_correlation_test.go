@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestCorrelatedLogHandler(t *testing.T) {
+	sc := testSpanContext(t, "")
+	sampled := trace.ContextWithSpanContext(context.Background(), sc)
+	unsampled := trace.ContextWithSpanContext(context.Background(), sc.WithTraceFlags(0))
+
+	tests := []struct {
+		name string
+		ctx  context.Context //nolint:containedctx
+		want string
+	}{
+		{"sampled", sampled, "msg=done component=api trace_id=0102030405060708090a0b0c0d0e0f10 span_id=0102030405060708\n"},
+		{"unsampled", unsampled, "msg=done component=api\n"},
+		{"no span", context.Background(), "msg=done component=api\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+						return slog.Attr{}
+					}
+					return a
+				},
+			})
+			logger := slog.New(CorrelatedLogHandler(handler)).With("component", "api")
+			logger.InfoContext(tt.ctx, "done")
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("log = %q, want %q", got, tt.want)
+			}
+			if got := len(LogFields(tt.ctx)); got != strings.Count(tt.want, "_id=") {
+				t.Errorf("LogFields() has %d attributes", got)
+			}
+		})
+	}
+}
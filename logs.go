@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+
+	"go.opentelemetry.io/contrib/bridges/otelslog"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	logsdk "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"google.golang.org/grpc"
+)
+
+// WithLogs exports logs to the collector alongside traces (over the same gRPC
+// connection, or to the same OTLP/HTTP collector). Logs are written to the
+// collector by Tracer.LogHandler, or by the slog default logger with
+// WithDefaultLogger, which also writes them to local (may be nil, e.g. when logs
+// are read only from the collector). Logs written with a context of a span carry
+// its trace and span IDs. It's ignored with WithStdoutExporter, WithFileExporter
+// and WithExporter.
+//
+// Only Info and above levels are exported, local handler decides for itself.
+func WithLogs(local slog.Handler) Option {
+	return func(opts *Options) {
+		opts.logs = &logsOptions{local: local}
+	}
+}
+
+// WithDefaultLogger makes Init replace the slog default logger with the one
+// writing to the collector and the local handler of WithLogs. The previous
+// default logger is restored on shutdown. It has no effect without WithLogs.
+func WithDefaultLogger() Option {
+	return func(opts *Options) {
+		opts.defaultLogger = true
+	}
+}
+
+type logsOptions struct {
+	local slog.Handler
+}
+
+// LogHandler returns the slog handler exporting logs to the collector, or nil if
+// WithLogs wasn't set.
+func (t *Tracer) LogHandler() slog.Handler {
+	if t.loggerProvider == nil {
+		return nil
+	}
+	return minLevelHandler{
+		Handler: otelslog.NewHandler("", otelslog.WithLoggerProvider(t.loggerProvider)),
+		level:   slog.LevelInfo,
+	}
+}
+
+// newLoggerProvider creates the logger provider exporting to the collector over
+// conn (nil for OTLP/HTTP).
+func newLoggerProvider(
+	ctx context.Context, options Options, conn *grpc.ClientConn, res *resource.Resource,
+) (*logsdk.LoggerProvider, error) {
+	var (
+		exporter logsdk.Exporter
+		err      error
+	)
+	if conn != nil {
+		opts := []otlploggrpc.Option{otlploggrpc.WithGRPCConn(conn)}
+		if len(options.collectorHeaders) > 0 {
+			opts = append(opts, otlploggrpc.WithHeaders(options.collectorHeaders))
+		}
+		exporter, err = otlploggrpc.New(ctx, opts...)
+	} else {
+		exporter, err = makeHTTPLogExporter(ctx, options)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create log exporter: %w", err)
+	}
+
+	return logsdk.NewLoggerProvider(
+		logsdk.WithResource(res),
+		logsdk.WithProcessor(logsdk.NewBatchProcessor(exporter)),
+	), nil
+}
+
+func makeHTTPLogExporter(ctx context.Context, options Options) (*otlploghttp.Exporter, error) {
+	cfg, err := newHTTPExporterConfig(options, "logs")
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []otlploghttp.Option{
+		otlploghttp.WithEndpoint(cfg.endpoint),
+	}
+	if cfg.tlsConfig != nil {
+		opts = append(opts, otlploghttp.WithTLSClientConfig(cfg.tlsConfig))
+	} else {
+		opts = append(opts, otlploghttp.WithInsecure())
+	}
+	if cfg.urlPath != "" {
+		opts = append(opts, otlploghttp.WithURLPath(cfg.urlPath))
+	}
+	if len(cfg.headers) > 0 {
+		opts = append(opts, otlploghttp.WithHeaders(cfg.headers))
+	}
+	if cfg.compression {
+		opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+	}
+
+	return otlploghttp.New(ctx, opts...)
+}
+
+// minLevelHandler passes records of the level and above to the next handler.
+type minLevelHandler struct {
+	slog.Handler
+
+	level slog.Level
+}
+
+func (h minLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level && h.Handler.Enabled(ctx, level)
+}
+
+func (h minLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return minLevelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+func (h minLevelHandler) WithGroup(name string) slog.Handler {
+	return minLevelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
+
+// savedLogger is the slog default logger and the log package output replaced
+// by setDefaultLogger.
+type savedLogger struct {
+	logger *slog.Logger
+	writer io.Writer
+	flags  int
+}
+
+// setDefaultLogger makes the handler the slog default and returns the replaced
+// default logger.
+func setDefaultLogger(handler slog.Handler) *savedLogger {
+	saved := &savedLogger{logger: slog.Default(), writer: log.Writer(), flags: log.Flags()}
+	slog.SetDefault(slog.New(handler))
+	return saved
+}
+
+// restore makes the saved logger the default again. slog.SetDefault redirects
+// the log package to the new handler, so its output is restored too.
+func (l *savedLogger) restore() {
+	slog.SetDefault(l.logger)
+	log.SetOutput(l.writer)
+	log.SetFlags(l.flags)
+}
+
+// teeHandler passes records to all handlers.
+type teeHandler []slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range t {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+//nolint:gocritic // slog.Handler signature.
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range t {
+		if h.Enabled(ctx, r.Level) {
+			errs = append(errs, h.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(teeHandler, len(t))
+	for i, h := range t {
+		handlers[i] = h.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	handlers := make(teeHandler, len(t))
+	for i, h := range t {
+		handlers[i] = h.WithGroup(name)
+	}
+	return handlers
+}
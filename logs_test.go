@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"log/slog"
+	"testing"
+	"time"
+
+	logsdk "go.opentelemetry.io/otel/sdk/log"
+)
+
+func TestLogHandlerLevel(t *testing.T) {
+	exporter := &logExporter{}
+	provider := logsdk.NewLoggerProvider(logsdk.WithProcessor(logsdk.NewSimpleProcessor(exporter)))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	handler := (&Tracer{loggerProvider: provider}).LogHandler()
+
+	tests := []struct {
+		level   slog.Level
+		enabled bool
+	}{
+		{slog.LevelDebug, false},
+		{slog.LevelInfo - 1, false},
+		{slog.LevelInfo, true},
+		{slog.LevelWarn, true},
+		{slog.LevelError, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			for _, h := range []slog.Handler{handler, handler.WithAttrs([]slog.Attr{slog.Int("k", 1)}), handler.WithGroup("g")} {
+				if enabled := h.Enabled(context.Background(), tt.level); enabled != tt.enabled {
+					t.Errorf("Enabled(%v) = %v, want %v", tt.level, enabled, tt.enabled)
+				}
+			}
+
+			exporter.records = nil
+			slog.New(handler).Log(context.Background(), tt.level, "message")
+			if exported := len(exporter.records) == 1; exported != tt.enabled {
+				t.Errorf("exported = %v, want %v", exported, tt.enabled)
+			}
+		})
+	}
+}
+
+// logExporter records exported logs.
+type logExporter struct {
+	records []logsdk.Record
+}
+
+func (e *logExporter) Export(_ context.Context, records []logsdk.Record) error {
+	e.records = append(e.records, records...)
+	return nil
+}
+
+func (e *logExporter) Shutdown(context.Context) error   { return nil }
+func (e *logExporter) ForceFlush(context.Context) error { return nil }
+
+func TestSetDefaultLogger(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous *slog.Logger
+	}{
+		{"log package default", slog.Default()},
+		{"custom", slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prevLogger, prevWriter, prevFlags := slog.Default(), log.Writer(), log.Flags()
+			t.Cleanup(func() {
+				slog.SetDefault(prevLogger)
+				log.SetOutput(prevWriter)
+				log.SetFlags(prevFlags)
+			})
+			slog.SetDefault(tt.previous)
+			writer, flags := log.Writer(), log.Flags()
+
+			var out bytes.Buffer
+			saved := setDefaultLogger(slog.NewTextHandler(&out, nil))
+			log.Print("replaced")
+			if !bytes.Contains(out.Bytes(), []byte("replaced")) {
+				t.Errorf("log output %q isn't redirected to the new default", out.String())
+			}
+
+			saved.restore()
+			if slog.Default() != tt.previous {
+				t.Error("slog default logger isn't restored")
+			}
+			if log.Writer() != writer || log.Flags() != flags {
+				t.Error("log output isn't restored")
+			}
+		})
+	}
+}
+
+func TestHTTPLogExporter(t *testing.T) {
+	collector, opts := newHTTPCollector(t)
+	opts = append(opts, WithCollectorURLPath("/otlp/v1/traces"), WithExportCompression(), WithLogs(nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tr, err := New(ctx, "app", "1.0.0", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slog.New(tr.LogHandler()).InfoContext(ctx, "message")
+	if err := tr.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	encoding, ok := collector.encoding("/otlp/v1/logs")
+	if !ok {
+		t.Fatalf("no logs exported to /otlp/v1/logs: %v", collector.requests)
+	}
+	if encoding != "gzip" {
+		t.Errorf("Content-Encoding = %q, want gzip", encoding)
+	}
+}
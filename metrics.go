@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/metric"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"google.golang.org/grpc"
+)
+
+// WithMetrics exports metrics to the collector alongside traces (over the same
+// gRPC connection, or to the same OTLP/HTTP collector). Init sets the meter
+// provider as the OpenTelemetry global. It's ignored with WithStdoutExporter,
+// WithFileExporter and WithExporter.
+func WithMetrics() Option {
+	return func(opts *Options) {
+		opts.metrics = true
+	}
+}
+
+// Meter returns the meter of the default tracer (see Init). It's a noop meter if
+// WithMetrics wasn't set.
+func Meter() metric.Meter {
+	return current().Meter()
+}
+
+// Meter returns the meter exporting to the collector, or a noop meter if
+// WithMetrics wasn't set.
+func (t *Tracer) Meter() metric.Meter {
+	if t.meterProvider == nil {
+		return noopmetric.NewMeterProvider().Meter("")
+	}
+	return t.meterProvider.Meter("")
+}
+
+// Counter returns the counter of the default tracer's meter (see Meter).
+// Instruments made before Init are noop.
+func Counter(name string, opts ...metric.Int64CounterOption) metric.Int64Counter {
+	return current().Counter(name, opts...)
+}
+
+// Counter returns the counter of the tracer's meter. Invalid instruments are
+// reported to the OpenTelemetry error handler, the returned counter is usable
+// anyway.
+func (t *Tracer) Counter(name string, opts ...metric.Int64CounterOption) metric.Int64Counter {
+	counter, err := t.Meter().Int64Counter(name, opts...)
+	if err != nil {
+		otel.Handle(fmt.Errorf("tracer: failed to create counter %q: %w", name, err))
+	}
+	return counter
+}
+
+// Histogram returns the histogram of the default tracer's meter (see Meter).
+// Instruments made before Init are noop.
+func Histogram(name string, opts ...metric.Float64HistogramOption) metric.Float64Histogram {
+	return current().Histogram(name, opts...)
+}
+
+// Histogram returns the histogram of the tracer's meter. Invalid instruments are
+// reported to the OpenTelemetry error handler, the returned histogram is usable
+// anyway.
+func (t *Tracer) Histogram(name string, opts ...metric.Float64HistogramOption) metric.Float64Histogram {
+	histogram, err := t.Meter().Float64Histogram(name, opts...)
+	if err != nil {
+		otel.Handle(fmt.Errorf("tracer: failed to create histogram %q: %w", name, err))
+	}
+	return histogram
+}
+
+// newMeterProvider creates the meter provider exporting to the collector over
+// conn (nil for OTLP/HTTP).
+func newMeterProvider(
+	ctx context.Context, options Options, conn *grpc.ClientConn, res *resource.Resource,
+) (*metricsdk.MeterProvider, error) {
+	var (
+		exporter metricsdk.Exporter
+		err      error
+	)
+	if conn != nil {
+		opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithGRPCConn(conn)}
+		if len(options.collectorHeaders) > 0 {
+			opts = append(opts, otlpmetricgrpc.WithHeaders(options.collectorHeaders))
+		}
+		exporter, err = otlpmetricgrpc.New(ctx, opts...)
+	} else {
+		exporter, err = makeHTTPMetricExporter(ctx, options)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metric exporter: %w", err)
+	}
+
+	return metricsdk.NewMeterProvider(
+		metricsdk.WithResource(res),
+		metricsdk.WithReader(metricsdk.NewPeriodicReader(exporter)),
+	), nil
+}
+
+func makeHTTPMetricExporter(ctx context.Context, options Options) (*otlpmetrichttp.Exporter, error) {
+	cfg, err := newHTTPExporterConfig(options, "metrics")
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpoint(cfg.endpoint),
+	}
+	if cfg.tlsConfig != nil {
+		opts = append(opts, otlpmetrichttp.WithTLSClientConfig(cfg.tlsConfig))
+	} else {
+		opts = append(opts, otlpmetrichttp.WithInsecure())
+	}
+	if cfg.urlPath != "" {
+		opts = append(opts, otlpmetrichttp.WithURLPath(cfg.urlPath))
+	}
+	if len(cfg.headers) > 0 {
+		opts = append(opts, otlpmetrichttp.WithHeaders(cfg.headers))
+	}
+	if cfg.compression {
+		opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+	}
+
+	return otlpmetrichttp.New(ctx, opts...)
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestHTTPMetricExporter(t *testing.T) {
+	collector, opts := newHTTPCollector(t)
+	opts = append(opts, WithCollectorURLPath("/otlp/v1/traces"), WithExportCompression(), WithMetrics())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tr, err := New(ctx, "app", "1.0.0", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter, err := tr.Meter().Int64Counter("requests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter.Add(ctx, 1)
+	if err := tr.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	encoding, ok := collector.encoding("/otlp/v1/metrics")
+	if !ok {
+		t.Fatalf("no metrics exported to /otlp/v1/metrics: %v", collector.requests)
+	}
+	if encoding != "gzip" {
+		t.Errorf("Content-Encoding = %q, want gzip", encoding)
+	}
+}
+
+func TestCounterAndHistogram(t *testing.T) {
+	reader := metricsdk.NewManualReader()
+	tr := &Tracer{meterProvider: metricsdk.NewMeterProvider(metricsdk.WithReader(reader))}
+
+	var reported []error
+	prev := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) { reported = append(reported, err) }))
+	t.Cleanup(func() { otel.SetErrorHandler(prev) })
+
+	ctx := context.Background()
+	tr.Counter("requests").Add(ctx, 2)
+	tr.Histogram("latency").Record(ctx, 0.5)
+	tr.Counter("1 invalid").Add(ctx, 1)
+	if len(reported) != 1 || !strings.HasPrefix(reported[0].Error(), "tracer: failed to create counter") {
+		t.Errorf("reported errors = %v, want the invalid counter", reported)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]any{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				got[m.Name] = data.DataPoints[0].Value
+			case metricdata.Histogram[float64]:
+				got[m.Name] = data.DataPoints[0].Sum
+			}
+		}
+	}
+	if got["requests"] != int64(2) || got["latency"] != 0.5 {
+		t.Errorf("metrics = %v, want requests 2 and latency 0.5", got)
+	}
+}
+
+func TestCounterWithoutMetrics(t *testing.T) {
+	// The noop instruments are usable without WithMetrics.
+	tr := &Tracer{}
+	tr.Counter("requests").Add(context.Background(), 1)
+	tr.Histogram("latency").Record(context.Background(), 1)
+}
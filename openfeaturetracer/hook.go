@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT
+
+// Package openfeaturetracer records OpenFeature flag evaluations on the span of
+// the evaluation context with a hook:
+//
+//	openfeature.AddHooks(openfeaturetracer.NewHook())
+//
+// Every evaluation adds the "feature_flag" event (see tracer.RecordFlagEvaluation)
+// with the flag key, variant, reason and provider name. Failed evaluations are
+// recorded with the "ERROR" reason.
+package openfeaturetracer
+
+import (
+	"context"
+
+	"github.com/open-feature/go-sdk/openfeature"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+)
+
+// NewHook returns the hook recording flag evaluations.
+func NewHook() openfeature.Hook {
+	return hook{}
+}
+
+type hook struct {
+	openfeature.UnimplementedHook
+}
+
+func (hook) After(
+	ctx context.Context, hookContext openfeature.HookContext,
+	details openfeature.InterfaceEvaluationDetails, _ openfeature.HookHints,
+) error {
+	tracer.RecordFlagEvaluation(ctx, tracer.FlagEvaluation{
+		Key:      hookContext.FlagKey(),
+		Variant:  details.Variant,
+		Reason:   string(details.Reason),
+		Provider: hookContext.ProviderMetadata().Name,
+	})
+	return nil
+}
+
+func (hook) Error(ctx context.Context, hookContext openfeature.HookContext, _ error, _ openfeature.HookHints) {
+	tracer.RecordFlagEvaluation(ctx, tracer.FlagEvaluation{
+		Key:      hookContext.FlagKey(),
+		Reason:   string(openfeature.ErrorReason),
+		Provider: hookContext.ProviderMetadata().Name,
+	})
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+
+package openfeaturetracer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/memprovider"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+	"github.com/cdnnow-pro/go-tracer/openfeaturetracer"
+	"github.com/cdnnow-pro/go-tracer/tracertest"
+)
+
+func TestHook(t *testing.T) {
+	provider := memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{
+		"new-checkout": {
+			Key:            "new-checkout",
+			State:          memprovider.Enabled,
+			DefaultVariant: "on",
+			Variants:       map[string]any{"on": true, "off": false},
+		},
+	})
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), provider); err != nil {
+		t.Fatal(err)
+	}
+	client := openfeature.NewClient(t.Name())
+	client.AddHooks(openfeaturetracer.NewHook())
+
+	tests := []struct {
+		name    string
+		flag    string
+		variant string
+		reason  string
+	}{
+		{"evaluated", "new-checkout", "on", string(openfeature.StaticReason)},
+		{"missing flag", "missing", "", string(openfeature.ErrorReason)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracertest.Init(t)
+
+			ctx, span := tracer.StartSpan(context.Background(), "request")
+			_, _ = client.BooleanValue(ctx, tt.flag, false, openfeature.EvaluationContext{})
+			span.End()
+
+			spans := tracertest.Spans(t)
+			if len(spans) != 1 || len(spans[0].Events()) != 1 {
+				t.Fatalf("spans = %v, want one span with the flag event", spans)
+			}
+			attrs := map[string]string{}
+			for _, kv := range spans[0].Events()[0].Attributes {
+				attrs[string(kv.Key)] = kv.Value.Emit()
+			}
+			want := map[string]string{
+				"feature_flag.key":               tt.flag,
+				"feature_flag.variant":           tt.variant,
+				"feature_flag.evaluation.reason": tt.reason,
+				"feature_flag.provider_name":     "InMemoryProvider",
+			}
+			for key, value := range want {
+				if attrs[key] != value {
+					t.Errorf("%s = %q, want %q", key, attrs[key], value)
+				}
+			}
+		})
+	}
+}
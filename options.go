@@ -3,13 +3,24 @@
 package tracer
 
 import (
+	"crypto/tls"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	defaultGrpcPort = 4317
+	defaultHTTPPort = 4318
 )
 
 var defaultOptions = []Option{
 	WithCollectorHost("localhost"),
-	WithCollectorPort(4317), //nolint:mnd
 }
 
 type Option func(opts *Options)
@@ -21,18 +32,158 @@ func Noop() Option {
 	}
 }
 
+// WithCollectorHost sets the collector host, "localhost" by default. It replaces
+// the gRPC target set before by WithCollectorEndpoint or the environment.
 func WithCollectorHost(host string) Option {
 	return func(opts *Options) {
 		opts.host = host
+		opts.endpoint = ""
 	}
 }
 
+// WithCollectorPort sets the collector port. By default it's 4317 for gRPC and
+// 4318 for HTTP. It replaces the gRPC target set before by WithCollectorEndpoint
+// or the environment.
 func WithCollectorPort(port uint16) Option {
 	return func(opts *Options) {
 		opts.port = port
+		opts.endpoint = ""
+	}
+}
+
+// WithCollectorEndpoint sets the full gRPC target of the collector instead of
+// the host and port, e.g. "unix:///var/run/otel.sock" for the node agent
+// listening on a unix domain socket, or "dns:///collector:4317". It's ignored
+// with WithHTTPExporter.
+func WithCollectorEndpoint(target string) Option {
+	return func(opts *Options) {
+		opts.endpoint = target
+	}
+}
+
+// WithCollectorHeaders adds the headers (gRPC metadata) to export requests of
+// all signals, e.g. API keys of managed backends. Can be used multiple times.
+func WithCollectorHeaders(headers map[string]string) Option {
+	return func(opts *Options) {
+		if opts.collectorHeaders == nil {
+			opts.collectorHeaders = make(map[string]string, len(headers))
+		}
+		maps.Copy(opts.collectorHeaders, headers)
+	}
+}
+
+// WithBearerToken authenticates export requests with the bearer token.
+func WithBearerToken(token string) Option {
+	return WithCollectorHeaders(map[string]string{"authorization": "Bearer " + token})
+}
+
+// WithHTTPExporter makes the tracer export spans over OTLP/HTTP instead of gRPC.
+func WithHTTPExporter() Option {
+	return func(opts *Options) {
+		opts.httpExporter = true
+	}
+}
+
+// WithCollectorURLPath sets the URL path of the OTLP/HTTP collector endpoint,
+// "/v1/traces" by default. Used only with WithHTTPExporter. Logs and metrics are
+// exported to "/v1/logs" and "/v1/metrics" under the path without its
+// "/v1/traces" suffix, e.g. "/otlp/v1/logs" for "/otlp/v1/traces".
+func WithCollectorURLPath(path string) Option {
+	return func(opts *Options) {
+		opts.urlPath = path
+	}
+}
+
+// WithBatchTimeout sets the max delay before the batch of spans is exported,
+// 5s by default.
+func WithBatchTimeout(timeout time.Duration) Option {
+	return func(opts *Options) {
+		opts.batcher.batchTimeout = timeout
+	}
+}
+
+// WithExportTimeout sets the max duration of the batch export, 30s by default.
+func WithExportTimeout(timeout time.Duration) Option {
+	return func(opts *Options) {
+		opts.batcher.exportTimeout = timeout
+	}
+}
+
+// WithMaxQueueSize sets the max number of spans waiting for export, 2048 by
+// default. Spans are dropped when the queue is full.
+func WithMaxQueueSize(size int) Option {
+	return func(opts *Options) {
+		opts.batcher.maxQueueSize = size
+	}
+}
+
+// WithMaxExportBatchSize sets the max number of spans in the exported batch,
+// 512 by default. It's capped by the queue size.
+func WithMaxExportBatchSize(size int) Option {
+	return func(opts *Options) {
+		opts.batcher.maxExportBatchSize = size
+	}
+}
+
+// WithExportCompression compresses exported spans with gzip, trading CPU for
+// bandwidth on expensive export links. With WithCollectorProbe, spans are
+// compressed anyway if the collector supports gzip.
+func WithExportCompression() Option {
+	return func(opts *Options) {
+		opts.exportCompression = true
+	}
+}
+
+// WithExportRetry sets the backoff of retried span exports: the first retry is
+// after initial, the interval grows up to maxInterval, and the export is dropped
+// after maxElapsed. By default 5s, 30s and 1m.
+func WithExportRetry(initial, maxInterval, maxElapsed time.Duration) Option {
+	return func(opts *Options) {
+		opts.exportRetry = &exportRetry{initial: initial, maxInterval: maxInterval, maxElapsed: maxElapsed}
 	}
 }
 
+// WithAdditionalExporter exports spans to exporter as well, e.g. to a local debug
+// sink alongside the collector. Each exporter gets its own span processor, the
+// closer shuts all of them down. Can be used multiple times.
+func WithAdditionalExporter(exporter tracesdk.SpanExporter) Option {
+	return func(opts *Options) {
+		opts.additionalExporters = append(opts.additionalExporters, exporter)
+	}
+}
+
+// WithSpanProcessor registers the processor on the tracer provider, e.g. to add
+// common attributes on start or to export to a secondary sink. Processors get
+// all spans independently of each other, so a processor can't drop spans from
+// the collector export. Processors are called in the order of registration,
+// before the exporting ones, and are shut down by the closer. Can be used
+// multiple times.
+func WithSpanProcessor(processor tracesdk.SpanProcessor) Option {
+	return func(opts *Options) {
+		opts.spanProcessors = append(opts.spanProcessors, processor)
+	}
+}
+
+// WithResourceAttributes adds attributes to the resource of all spans, e.g.
+// "deployment.environment" or "k8s.cluster.name". Values are of the types
+// supported by Span.Tag, others are ignored. The service name and version can't
+// be overridden.
+func WithResourceAttributes(attrs map[string]any) Option {
+	return func(opts *Options) {
+		for _, key := range slices.Sorted(maps.Keys(attrs)) {
+			if kv, ok := attributeOf(key, attrs[key]); ok {
+				opts.resourceAttributes = append(opts.resourceAttributes, kv)
+			}
+		}
+	}
+}
+
+// WithResourceAttribute adds the attribute to the resource of all spans (see
+// WithResourceAttributes).
+func WithResourceAttribute(key string, value any) Option {
+	return WithResourceAttributes(map[string]any{key: value})
+}
+
 func WithKeepaliveTime(val time.Duration) Option {
 	return func(opts *Options) {
 		opts.keepaliveTime = &val
@@ -51,15 +202,112 @@ func WithKeepalivePermitWithoutStream(val bool) Option {
 	}
 }
 
+// WithRuntimeStats enables sampling of runtime stats (heap in use, goroutine count)
+// at the start and the end of root spans. The values at start and the deltas are
+// attached to the span as attributes.
+func WithRuntimeStats() Option {
+	return func(opts *Options) {
+		opts.runtimeStats = true
+	}
+}
+
+// WithFlushInterval force-flushes the tracer provider every interval, independently
+// of the batch processor. Useful for very low traffic services, where spans
+// otherwise sit in the queue for a long time.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(opts *Options) {
+		opts.flushInterval = interval
+	}
+}
+
 type Options struct {
 	keepaliveTime                *time.Duration
 	keepaliveTimeout             *time.Duration
 	keepalivePermitWithoutStream *bool
 
-	host string
-	port uint16
+	batcher                batcherConfig
+	flushInterval          time.Duration
+	collectorProbe         time.Duration
+	startupTimeout         time.Duration
+	deadlineMargin         time.Duration
+	clockOffset            func() time.Duration
+	compressionMaxDuration time.Duration
+	spanSizeLimit          int
 
-	noop bool
+	cancelPolicy    CancelPolicy
+	errorClassifier func(error) ErrorClass
+	tagFallback     TagFallback
+	droppedTagHook  func(key string, value any)
+
+	durationReport   *durationReportOptions
+	exportPacing     *ExportPacing
+	encryption       *attributeEncryption
+	cardinalityLimit *CardinalityLimit
+	logs             *logsOptions
+	defaultLogger    bool
+	faultInjection   *faultInjection
+
+	sampler           tracesdk.Sampler
+	syntheticSampling *float64
+	samplingReplay    *samplingReplayOptions
+	traceStateTiers   *traceStateTiers
+
+	identity identityPolicy
+
+	propagators     []propagation.TextMapPropagator
+	headerAliases   map[string]string
+	requestIDHeader string
+	trustPolicy     TrustPolicy
+
+	host             string
+	port             uint16
+	endpoint         string
+	collectorHeaders map[string]string
+
+	httpExporter        bool
+	exportCompression   bool
+	exportRetry         *exportRetry
+	urlPath             string
+	localExporter       *localExporter
+	auditTrail          *auditTrail
+	additionalExporters []tracesdk.SpanExporter
+	spanProcessors      []tracesdk.SpanProcessor
+	pipeline            *processorPipeline
+
+	tlsConfig *tls.Config
+	tlsFiles  *tlsFiles
+
+	serviceName        string
+	resourceAttributes []attribute.KeyValue
+
+	envConfig      bool
+	noop           bool
+	lazyConnect    bool
+	metrics        bool
+	runtimeStats   bool
+	queueTelemetry bool
+	serverless     bool
+	cliMode        bool
+	criticalLane   bool
+
+	propagationAudit    bool
+	orphanSpanStacks    bool
+	attributeValidation bool
+}
+
+// exportRetry is the backoff of retried span exports.
+type exportRetry struct {
+	initial     time.Duration
+	maxInterval time.Duration
+	maxElapsed  time.Duration
+}
+
+// batcherConfig is the batch span processor config, zero values are defaults.
+type batcherConfig struct {
+	batchTimeout       time.Duration
+	exportTimeout      time.Duration
+	maxQueueSize       int
+	maxExportBatchSize int
 }
 
 func buildOptions(opts []Option) Options {
@@ -70,11 +318,41 @@ func buildOptions(opts []Option) Options {
 		opt(&options)
 	}
 
+	if options.envConfig {
+		// Apply the environment first, so explicit options win.
+		options = Options{}
+		opts = append(append(defaultOptions, envOptions()...), opts[len(defaultOptions):]...)
+		for _, opt := range opts {
+			opt(&options)
+		}
+	}
+
 	return options
 }
 
+// GetGrpcTarget returns the gRPC target of the collector: the one set by
+// WithCollectorEndpoint, otherwise "host:port".
 func (o Options) GetGrpcTarget() string {
-	return fmt.Sprintf("%s:%d", o.host, o.port)
+	if o.endpoint != "" {
+		return o.endpoint
+	}
+	return fmt.Sprintf("%s:%d", o.host, o.collectorPort())
+}
+
+// GetHTTPEndpoint returns the "host:port" of the OTLP/HTTP collector.
+func (o Options) GetHTTPEndpoint() string {
+	return fmt.Sprintf("%s:%d", o.host, o.collectorPort())
+}
+
+func (o Options) collectorPort() uint16 {
+	switch {
+	case o.port != 0:
+		return o.port
+	case o.httpExporter:
+		return defaultHTTPPort
+	default:
+		return defaultGrpcPort
+	}
 }
 
 func (o Options) IsNoop() bool {
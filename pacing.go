@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ExportPacing spreads span exports over time, for edge nodes with constrained
+// uplinks where telemetry must not compete with customer traffic.
+type ExportPacing struct {
+	// MaxConcurrent is the max number of concurrent exports across all exporters
+	// of the tracer, unlimited if 0.
+	MaxConcurrent int
+
+	// Jitter is the max random delay before each export.
+	Jitter time.Duration
+
+	// IsPeak reports whether the time is within peak traffic, when exports are
+	// additionally delayed by PeakDelay. May be nil.
+	IsPeak    func(time.Time) bool
+	PeakDelay time.Duration
+}
+
+// WithExportPacing paces span exports. Delays are taken from the export timeout
+// (see WithExportTimeout), and are capped by the half of it, so exports aren't
+// failed by pacing alone.
+func WithExportPacing(pacing ExportPacing) Option {
+	return func(opts *Options) {
+		opts.exportPacing = &pacing
+	}
+}
+
+// exportPacer paces exports of the wrapped exporters.
+type exportPacer struct {
+	pacing ExportPacing
+	slots  chan struct{} // nil if unlimited
+}
+
+// newExportPacer returns the pacer, nil if pacing isn't set.
+func newExportPacer(pacing *ExportPacing) *exportPacer {
+	if pacing == nil {
+		return nil
+	}
+
+	p := &exportPacer{pacing: *pacing}
+	if pacing.MaxConcurrent > 0 {
+		p.slots = make(chan struct{}, pacing.MaxConcurrent)
+	}
+	return p
+}
+
+// wrap returns exporter paced by p, exporter itself if p is nil.
+func (p *exportPacer) wrap(exporter tracesdk.SpanExporter) tracesdk.SpanExporter {
+	if p == nil {
+		return exporter
+	}
+	return pacedExporter{SpanExporter: exporter, pacer: p}
+}
+
+// delay returns the delay before the export started now.
+func (p *exportPacer) delay(now time.Time) time.Duration {
+	var delay time.Duration
+	if p.pacing.Jitter > 0 {
+		delay = rand.N(p.pacing.Jitter) //nolint:gosec
+	}
+	if p.pacing.IsPeak != nil && p.pacing.IsPeak(now) {
+		delay += p.pacing.PeakDelay
+	}
+	return delay
+}
+
+// wait waits for the export turn. Returns the ctx error if ctx is done first.
+func (p *exportPacer) wait(ctx context.Context) (release func(), err error) {
+	now := time.Now()
+	delay := p.delay(now)
+	if deadline, ok := ctx.Deadline(); ok {
+		delay = min(delay, deadline.Sub(now)/2) //nolint:mnd
+	}
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if p.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case p.slots <- struct{}{}:
+		return func() { <-p.slots }, nil
+	}
+}
+
+// pacedExporter waits for the export turn before each export.
+type pacedExporter struct {
+	tracesdk.SpanExporter
+
+	pacer *exportPacer
+}
+
+func (e pacedExporter) ExportSpans(ctx context.Context, spans []tracesdk.ReadOnlySpan) error {
+	release, err := e.pacer.wait(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return e.SpanExporter.ExportSpans(ctx, spans) //nolint:wrapcheck
+}
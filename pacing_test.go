@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestExportPacerDelay(t *testing.T) {
+	peak := func(time.Time) bool { return true }
+	tests := []struct {
+		name     string
+		pacing   ExportPacing
+		min, max time.Duration
+	}{
+		{"none", ExportPacing{}, 0, 0},
+		{"jitter", ExportPacing{Jitter: 10 * time.Millisecond}, 0, 10 * time.Millisecond},
+		{"peak", ExportPacing{IsPeak: peak, PeakDelay: time.Second}, time.Second, time.Second},
+		{"off peak", ExportPacing{IsPeak: func(time.Time) bool { return false }, PeakDelay: time.Second}, 0, 0},
+		{"peak with jitter", ExportPacing{Jitter: time.Millisecond, IsPeak: peak, PeakDelay: time.Second}, time.Second, time.Second + time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newExportPacer(&tt.pacing)
+			for range 100 {
+				if delay := p.delay(time.Now()); delay < tt.min || delay > tt.max {
+					t.Fatalf("delay = %v, want in [%v, %v]", delay, tt.min, tt.max)
+				}
+			}
+		})
+	}
+
+	if p := newExportPacer(nil); p.wrap(exportFunc(nil)) == nil {
+		t.Error("nil pacer doesn't return the exporter")
+	}
+}
+
+func TestPacedExporterConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	exporter := exportFunc(func(context.Context, []tracesdk.ReadOnlySpan) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+	e := newExportPacer(&ExportPacing{MaxConcurrent: 2}).wrap(exporter)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = e.ExportSpans(context.Background(), nil)
+		}()
+	}
+	wg.Wait()
+	if got := peak.Load(); got != 2 {
+		t.Errorf("max concurrent exports = %d, want 2", got)
+	}
+}
+
+func TestPacedExporterDeadline(t *testing.T) {
+	var exported bool
+	e := newExportPacer(&ExportPacing{IsPeak: func(time.Time) bool { return true }, PeakDelay: time.Hour}).
+		wrap(exportFunc(func(context.Context, []tracesdk.ReadOnlySpan) error {
+			exported = true
+			return nil
+		}))
+
+	// The delay is capped by the half of the export timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := e.ExportSpans(ctx, nil); err != nil || !exported {
+		t.Errorf("export err = %v, exported = %v, want exported within the timeout", err, exported)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := e.ExportSpans(ctx, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled export err = %v, want context.Canceled", err)
+	}
+}
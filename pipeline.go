@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SpanProcessor is the processor of the span processor pipeline (see
+// WithProcessorPipeline). Unlike processors of WithSpanProcessor, it can modify
+// and drop spans before export. It's called concurrently.
+type SpanProcessor interface {
+	// Process returns the span passed to the next processor, or nil to drop it.
+	Process(s tracesdk.ReadOnlySpan) tracesdk.ReadOnlySpan
+}
+
+// SpanProcessorFunc is the SpanProcessor function.
+type SpanProcessorFunc func(s tracesdk.ReadOnlySpan) tracesdk.ReadOnlySpan
+
+func (f SpanProcessorFunc) Process(s tracesdk.ReadOnlySpan) tracesdk.ReadOnlySpan {
+	return f(s)
+}
+
+// Redactor is the SpanProcessor redacting spans, which must run after all other
+// processors of the pipeline.
+type Redactor interface {
+	SpanProcessor
+
+	// Redacts reports whether the processor redacts spans.
+	Redacts() bool
+}
+
+// Redaction marks the processor as the redacting one (see Redactor).
+func Redaction(processor SpanProcessor) Redactor {
+	return pipelineStage{kind: stageRedact, process: processor.Process}
+}
+
+const (
+	stageEnrich = "enrich"
+	stageFilter = "filter"
+	stageRedact = "redact"
+	stageCustom = "custom"
+)
+
+// pipelineStage is the built-in pipeline processor.
+type pipelineStage struct {
+	kind    string
+	process func(s tracesdk.ReadOnlySpan) tracesdk.ReadOnlySpan // nil drops s
+}
+
+func (p pipelineStage) Process(s tracesdk.ReadOnlySpan) tracesdk.ReadOnlySpan {
+	return p.process(s)
+}
+
+func (p pipelineStage) Redacts() bool {
+	return p.kind == stageRedact
+}
+
+// EnrichSpans adds the attributes returned by enrich to ended spans, replacing
+// the attributes with the same keys.
+func EnrichSpans(enrich func(s tracesdk.ReadOnlySpan) []attribute.KeyValue) SpanProcessor {
+	return pipelineStage{kind: stageEnrich, process: func(s tracesdk.ReadOnlySpan) tracesdk.ReadOnlySpan {
+		extra := enrich(s)
+		if len(extra) == 0 {
+			return s
+		}
+		attrs := slices.Clone(s.Attributes())
+		for _, kv := range extra {
+			if i := slices.IndexFunc(attrs, func(a attribute.KeyValue) bool { return a.Key == kv.Key }); i >= 0 {
+				attrs[i] = kv
+			} else {
+				attrs = append(attrs, kv)
+			}
+		}
+		return processedSpan{ReadOnlySpan: s, attrs: attrs, events: s.Events()}
+	}}
+}
+
+// FilterSpans drops ended spans keep returns false for. Dropped spans aren't
+// reported as abandoned on shutdown.
+func FilterSpans(keep func(s tracesdk.ReadOnlySpan) bool) SpanProcessor {
+	return pipelineStage{kind: stageFilter, process: func(s tracesdk.ReadOnlySpan) tracesdk.ReadOnlySpan {
+		if !keep(s) {
+			return nil
+		}
+		return s
+	}}
+}
+
+// RedactAttributes passes the attributes of ended spans and their events through
+// redact, which returns the attribute to export (e.g. with the value masked), or
+// false to remove it.
+func RedactAttributes(redact func(kv attribute.KeyValue) (attribute.KeyValue, bool)) Redactor {
+	redactAll := func(attrs []attribute.KeyValue) []attribute.KeyValue {
+		redacted := make([]attribute.KeyValue, 0, len(attrs))
+		for _, kv := range attrs {
+			if kv, ok := redact(kv); ok {
+				redacted = append(redacted, kv)
+			}
+		}
+		return redacted
+	}
+
+	return pipelineStage{kind: stageRedact, process: func(s tracesdk.ReadOnlySpan) tracesdk.ReadOnlySpan {
+		events := slices.Clone(s.Events())
+		for i := range events {
+			events[i].Attributes = redactAll(events[i].Attributes)
+		}
+		return processedSpan{ReadOnlySpan: s, attrs: redactAll(s.Attributes()), events: events}
+	}}
+}
+
+// WithProcessorPipeline processes ended spans by the processors in the given
+// order before they are exported by any route: the collector, the additional
+// exporters (see WithAdditionalExporter) and the audit trail. Redactors (see
+// Redactor, RedactAttributes and Redaction) must run last, after all the
+// processors that add or keep data, so enriched attributes can't bypass
+// redaction; Init fails otherwise. Processors of WithSpanProcessor get the spans
+// unprocessed.
+func WithProcessorPipeline(processors []SpanProcessor) Option {
+	return func(opts *Options) {
+		pipeline := &processorPipeline{processors: processors}
+		redacting := false
+		for i, processor := range processors {
+			switch {
+			case redacts(processor):
+				redacting = true
+			case redacting:
+				pipeline.err = fmt.Errorf("invalid processor pipeline: %s processor %d after redaction",
+					processorKind(processor), i)
+			}
+		}
+		opts.pipeline = pipeline
+	}
+}
+
+// redacts reports whether the processor is the Redactor.
+func redacts(processor SpanProcessor) bool {
+	r, ok := processor.(Redactor)
+	return ok && r.Redacts()
+}
+
+// processorKind returns the kind of the pipeline processor for diagnostics.
+func processorKind(processor SpanProcessor) string {
+	switch p := processor.(type) {
+	case pipelineStage:
+		return p.kind
+	case Redactor:
+		if p.Redacts() {
+			return stageRedact
+		}
+	}
+	return stageCustom
+}
+
+type processorPipeline struct {
+	processors []SpanProcessor
+	err        error
+}
+
+// kinds returns the processor kinds in order.
+func (p *processorPipeline) kinds() []string {
+	if p == nil {
+		return nil
+	}
+	kinds := make([]string, len(p.processors))
+	for i, processor := range p.processors {
+		kinds[i] = processorKind(processor)
+	}
+	return kinds
+}
+
+// wrap returns processor processing spans by the pipeline first, processor
+// itself if p is nil. Filtered spans are counted by stats, if any.
+func (p *processorPipeline) wrap(processor tracesdk.SpanProcessor, stats *exportStats) tracesdk.SpanProcessor {
+	if p == nil || len(p.processors) == 0 {
+		return processor
+	}
+	return pipelineProcessor{next: processor, processors: p.processors, stats: stats}
+}
+
+// pipelineProcessor processes spans by the pipeline processors before passing
+// them to the next processor.
+type pipelineProcessor struct {
+	next       tracesdk.SpanProcessor
+	processors []SpanProcessor
+	stats      *exportStats
+}
+
+var _ tracesdk.SpanProcessor = pipelineProcessor{}
+
+func (p pipelineProcessor) OnStart(parent context.Context, s tracesdk.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p pipelineProcessor) OnEnd(s tracesdk.ReadOnlySpan) {
+	if !s.SpanContext().IsSampled() {
+		return
+	}
+	for _, processor := range p.processors {
+		if s = processor.Process(s); s == nil {
+			if p.stats != nil {
+				p.stats.filtered.Add(1)
+			}
+			return
+		}
+	}
+	p.next.OnEnd(s)
+}
+
+func (p pipelineProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p pipelineProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// processedSpan is the span with attributes and events replaced by the pipeline.
+type processedSpan struct {
+	tracesdk.ReadOnlySpan
+
+	attrs  []attribute.KeyValue
+	events []tracesdk.Event
+}
+
+func (s processedSpan) Attributes() []attribute.KeyValue {
+	return s.attrs
+}
+
+func (s processedSpan) Events() []tracesdk.Event {
+	return s.events
+}
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithProcessorPipeline(t *testing.T) {
+	enrich := EnrichSpans(func(tracesdk.ReadOnlySpan) []attribute.KeyValue { return nil })
+	filter := FilterSpans(func(tracesdk.ReadOnlySpan) bool { return true })
+	redact := RedactAttributes(func(kv attribute.KeyValue) (attribute.KeyValue, bool) { return kv, true })
+	custom := SpanProcessorFunc(func(s tracesdk.ReadOnlySpan) tracesdk.ReadOnlySpan { return s })
+
+	tests := []struct {
+		name       string
+		processors []SpanProcessor
+		kinds      []string
+		wantErr    bool
+	}{
+		{"empty", nil, []string{}, false},
+		{"redaction last", []SpanProcessor{enrich, filter, custom, redact}, []string{"enrich", "filter", "custom", "redact"}, false},
+		{"redactions", []SpanProcessor{redact, Redaction(custom)}, []string{"redact", "redact"}, false},
+		{"enrich after redaction", []SpanProcessor{redact, enrich}, []string{"redact", "enrich"}, true},
+		{"custom after redaction", []SpanProcessor{Redaction(custom), custom}, []string{"redact", "custom"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := buildOptions([]Option{WithProcessorPipeline(tt.processors)})
+			if err := options.pipeline.err; (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if kinds := options.pipeline.kinds(); len(kinds) != len(tt.kinds) {
+				t.Errorf("kinds = %v, want %v", kinds, tt.kinds)
+			} else {
+				for i := range kinds {
+					if kinds[i] != tt.kinds[i] {
+						t.Errorf("kinds = %v, want %v", kinds, tt.kinds)
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPipelineProcessor(t *testing.T) {
+	secret := attribute.String("user.email", "user@example.com")
+	mask := RedactAttributes(func(kv attribute.KeyValue) (attribute.KeyValue, bool) {
+		if kv.Key == secret.Key {
+			return attribute.String(string(kv.Key), "***"), true
+		}
+		return kv, true
+	})
+
+	tests := []struct {
+		name       string
+		processors []SpanProcessor
+		exported   bool
+		attrs      map[attribute.Key]string
+	}{
+		{
+			"enrich and redact",
+			[]SpanProcessor{EnrichSpans(func(tracesdk.ReadOnlySpan) []attribute.KeyValue {
+				return []attribute.KeyValue{secret}
+			}), mask},
+			true,
+			map[attribute.Key]string{"k": "v", secret.Key: "***"},
+		},
+		{
+			"filter",
+			[]SpanProcessor{FilterSpans(func(tracesdk.ReadOnlySpan) bool { return false }), mask},
+			false, nil,
+		},
+		{
+			"custom drop",
+			[]SpanProcessor{SpanProcessorFunc(func(tracesdk.ReadOnlySpan) tracesdk.ReadOnlySpan { return nil })},
+			false, nil,
+		},
+		{
+			"custom redaction",
+			[]SpanProcessor{Redaction(SpanProcessorFunc(func(s tracesdk.ReadOnlySpan) tracesdk.ReadOnlySpan {
+				return processedSpan{ReadOnlySpan: s, events: s.Events()}
+			}))},
+			true,
+			map[attribute.Key]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			stats := &exportStats{}
+			pipeline := &processorPipeline{processors: tt.processors}
+			p := pipeline.wrap(recorder, stats)
+
+			p.OnEnd(tracetest.SpanStub{
+				Name: "span",
+				SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+					TraceID: trace.TraceID{1}, SpanID: trace.SpanID{1}, TraceFlags: trace.FlagsSampled,
+				}),
+				Attributes: []attribute.KeyValue{attribute.String("k", "v")},
+			}.Snapshot())
+
+			ended := recorder.Ended()
+			if exported := len(ended) == 1; exported != tt.exported {
+				t.Fatalf("exported = %v, want %v", exported, tt.exported)
+			}
+			if !tt.exported {
+				if filtered := stats.filtered.Load(); filtered != 1 {
+					t.Errorf("filtered = %d, want 1", filtered)
+				}
+				return
+			}
+
+			attrs := ended[0].Attributes()
+			if len(attrs) != len(tt.attrs) {
+				t.Errorf("attributes = %v, want %v", attrs, tt.attrs)
+			}
+			for _, kv := range attrs {
+				if want, ok := tt.attrs[kv.Key]; !ok || kv.Value.AsString() != want {
+					t.Errorf("%s = %q, want %q", kv.Key, kv.Value.AsString(), want)
+				}
+			}
+		})
+	}
+}
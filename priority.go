@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/cdnnow-pro/go-tracer/keys"
+)
+
+// criticalQueueSize is the queue size of the critical spans lane.
+const criticalQueueSize = 8192
+
+// WithCriticalLane routes spans tagged with keys.Critical (e.g. payment flows)
+// through a dedicated queue that is flushed first and never drops spans: when
+// it's full, ending a critical span blocks until there is room. Other spans go
+// through the regular queue, which drops spans under backpressure.
+//
+//	keys.Critical.Set(span, true)
+func WithCriticalLane() Option {
+	return func(opts *Options) {
+		opts.criticalLane = true
+	}
+}
+
+// priorityProcessor routes critical spans to the critical processor and others
+// to the bulk processor. Both export to the shared exporter, which is shut down
+// once both of them are drained.
+type priorityProcessor struct {
+	critical tracesdk.SpanProcessor
+	bulk     tracesdk.SpanProcessor
+	exporter tracesdk.SpanExporter
+}
+
+var _ tracesdk.SpanProcessor = priorityProcessor{}
+
+func newPriorityProcessor(exporter tracesdk.SpanExporter, options Options) priorityProcessor {
+	criticalOpts := append(batcherOptions(options),
+		tracesdk.WithBlocking(),
+		tracesdk.WithMaxQueueSize(criticalQueueSize),
+	)
+
+	shared := sharedExporter{SpanExporter: exporter}
+	return priorityProcessor{
+		critical: tracesdk.NewBatchSpanProcessor(shared, criticalOpts...),
+		bulk:     tracesdk.NewBatchSpanProcessor(shared, batcherOptions(options)...),
+		exporter: exporter,
+	}
+}
+
+func (p priorityProcessor) OnStart(parent context.Context, s tracesdk.ReadWriteSpan) {
+	p.critical.OnStart(parent, s)
+	p.bulk.OnStart(parent, s)
+}
+
+func (p priorityProcessor) OnEnd(s tracesdk.ReadOnlySpan) {
+	if isCritical(s) {
+		p.critical.OnEnd(s)
+	} else {
+		p.bulk.OnEnd(s)
+	}
+}
+
+func (p priorityProcessor) Shutdown(ctx context.Context) error {
+	err := errors.Join(p.critical.Shutdown(ctx), p.bulk.Shutdown(ctx))
+	return errors.Join(err, p.exporter.Shutdown(ctx))
+}
+
+func (p priorityProcessor) ForceFlush(ctx context.Context) error {
+	return errors.Join(p.critical.ForceFlush(ctx), p.bulk.ForceFlush(ctx))
+}
+
+// sharedExporter is the exporter shared by processors, so it isn't shut down by
+// them.
+type sharedExporter struct {
+	tracesdk.SpanExporter
+}
+
+func (sharedExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func isCritical(s tracesdk.ReadOnlySpan) bool {
+	for _, kv := range s.Attributes() {
+		if kv.Key == keys.Critical.Key() {
+			return kv.Value.AsBool()
+		}
+	}
+	return false
+}
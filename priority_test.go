@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/cdnnow-pro/go-tracer/keys"
+)
+
+// closingExporter fails exports after shutdown.
+type closingExporter struct {
+	mu       sync.Mutex
+	exported []string
+	shutdown int
+}
+
+func (e *closingExporter) ExportSpans(_ context.Context, spans []tracesdk.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.shutdown > 0 {
+		return errors.New("exporter is shut down")
+	}
+	for _, s := range spans {
+		e.exported = append(e.exported, s.Name())
+	}
+	return nil
+}
+
+func (e *closingExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown++
+	return nil
+}
+
+func TestPriorityProcessorShutdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		spans map[string]bool // name: critical
+	}{
+		{"bulk", map[string]bool{"bulk": false}},
+		{"critical", map[string]bool{"critical": true}},
+		{"both", map[string]bool{"bulk": false, "critical": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := &closingExporter{}
+			tp := tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(newPriorityProcessor(exporter, Options{})))
+			for name, critical := range tt.spans {
+				_, s := tp.Tracer("").Start(context.Background(), name)
+				s.SetAttributes(attribute.Bool(string(keys.Critical.Key()), critical))
+				s.End()
+			}
+
+			if err := tp.Shutdown(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if len(exporter.exported) != len(tt.spans) {
+				t.Errorf("exported %v, want %d spans", exporter.exported, len(tt.spans))
+			}
+			if exporter.shutdown != 1 {
+				t.Errorf("exporter shut down %d times, want once", exporter.shutdown)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// WithCollectorProbe probes the gRPC collector at Init with empty exports, within
+// the timeout: first gzip compressed, then uncompressed if the collector doesn't
+// support gzip. If gzip is supported, spans are exported compressed. The result
+// is returned by Tracer.Capabilities and probe errors are reported to the
+// OpenTelemetry error handler. Init doesn't fail if the collector is
+// unreachable, spans are exported once it becomes available.
+//
+// Partial success responses need no probing: they are always reported to the
+// OpenTelemetry error handler.
+func WithCollectorProbe(timeout time.Duration) Option {
+	return func(opts *Options) {
+		opts.collectorProbe = timeout
+	}
+}
+
+// CollectorCapabilities is the result of the collector probe.
+type CollectorCapabilities struct {
+	// Probed reports whether the collector was probed (see WithCollectorProbe).
+	Probed bool
+	// Reachable reports whether the collector accepted the probe export.
+	Reachable bool
+	// Gzip reports whether the collector supports gzip compression.
+	Gzip bool
+	// Err is the error of the last probe export, if any.
+	Err error
+}
+
+// Capabilities returns the collector capabilities of the default tracer.
+func Capabilities() CollectorCapabilities {
+	return current().Capabilities()
+}
+
+// Capabilities returns the collector capabilities probed at New (zero if
+// WithCollectorProbe wasn't set).
+func (t *Tracer) Capabilities() CollectorCapabilities {
+	return t.capabilities
+}
+
+// probeCollector probes the collector over conn (nil for OTLP/HTTP and local
+// exporters, which aren't probed).
+func probeCollector(ctx context.Context, conn *grpc.ClientConn, options Options) CollectorCapabilities {
+	if conn == nil {
+		return CollectorCapabilities{}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, options.collectorProbe)
+	defer cancel()
+	if len(options.collectorHeaders) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(options.collectorHeaders))
+	}
+
+	client := coltracepb.NewTraceServiceClient(conn)
+	caps := CollectorCapabilities{Probed: true}
+
+	_, err := client.Export(ctx, &coltracepb.ExportTraceServiceRequest{},
+		grpc.UseCompressor(gzip.Name), grpc.WaitForReady(true))
+	if err == nil {
+		caps.Reachable, caps.Gzip = true, true
+		return caps
+	}
+	// Servers without the decompressor reply with Unimplemented.
+	if status.Code(err) != codes.Unimplemented {
+		caps.Err = err
+		otel.Handle(fmt.Errorf("tracer: collector probe failed: %w", err))
+		return caps
+	}
+
+	_, err = client.Export(ctx, &coltracepb.ExportTraceServiceRequest{}, grpc.WaitForReady(true))
+	caps.Reachable = err == nil
+	caps.Err = err
+	if err != nil {
+		otel.Handle(fmt.Errorf("tracer: collector probe failed: %w", err))
+	}
+	return caps
+}
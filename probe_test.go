@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestCollectorProbe(t *testing.T) {
+	collector, addr := startTraceCollector(t)
+
+	tr, err := New(context.Background(), "app", "1.0.0", WithCollectorEndpoint(addr),
+		WithCollectorHeaders(map[string]string{"x-api-key": "secret"}), WithCollectorProbe(5*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = tr.Shutdown(context.Background()) })
+
+	caps := tr.Capabilities()
+	if !caps.Probed || !caps.Reachable || !caps.Gzip || caps.Err != nil {
+		t.Errorf("capabilities = %+v, want reachable with gzip", caps)
+	}
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	if got := collector.md.Get("x-api-key"); len(got) != 1 || got[0] != "secret" {
+		t.Errorf("probe x-api-key = %v, want [secret]", got)
+	}
+}
+
+func TestCollectorProbeUnreachable(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := lis.Addr().String()
+	_ = lis.Close()
+
+	tr, err := New(context.Background(), "app", "1.0.0", WithCollectorEndpoint(addr), WithCollectorProbe(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New() with the unreachable collector: %v", err)
+	}
+	t.Cleanup(func() { _ = tr.Shutdown(context.Background()) })
+
+	if caps := tr.Capabilities(); !caps.Probed || caps.Reachable || caps.Err == nil {
+		t.Errorf("capabilities = %+v, want unreachable with the error", caps)
+	}
+}
+
+func TestCollectorNotProbed(t *testing.T) {
+	tr, err := New(context.Background(), "app", "1.0.0", WithCollectorProbe(time.Second), WithStdoutExporter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = tr.Shutdown(context.Background()) })
+
+	if caps := tr.Capabilities(); caps.Probed {
+		t.Errorf("capabilities = %+v, want the local exporter not probed", caps)
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import "time"
+
+// ProfileHighThroughput tunes the tracer for services handling many requests:
+// 10% of root spans are sampled, spans are exported in large batches from a deep
+// queue and spans over 64 KiB are truncated. Options after the profile override
+// its settings.
+func ProfileHighThroughput() Option {
+	return profile(
+		WithSampleRatio(0.1),            //nolint:mnd
+		WithBatchTimeout(5*time.Second), //nolint:mnd
+		WithMaxQueueSize(16384),         //nolint:mnd
+		WithMaxExportBatchSize(2048),    //nolint:mnd
+		WithSpanSizeLimit(64*1024),      //nolint:mnd
+	)
+}
+
+// ProfileLowLatency tunes the tracer for latency-sensitive services: spans are
+// exported in small batches shortly after they end, and slow exports are cut
+// short. Options after the profile override its settings.
+func ProfileLowLatency() Option {
+	return profile(
+		WithBatchTimeout(200*time.Millisecond), //nolint:mnd
+		WithExportTimeout(5*time.Second),       //nolint:mnd
+		WithMaxExportBatchSize(128),            //nolint:mnd
+	)
+}
+
+// ProfileDebug tunes the tracer for local development and troubleshooting: all
+// spans are sampled and exported almost immediately, and attribute values are
+// validated. Options after the profile override its settings.
+func ProfileDebug() Option {
+	return profile(
+		WithAlwaysSample(),
+		WithBatchTimeout(100*time.Millisecond), //nolint:mnd
+		WithAttributeValidation(),
+	)
+}
+
+// profile combines the options into one.
+func profile(opts ...Option) Option {
+	return func(options *Options) {
+		for _, opt := range opts {
+			opt(options)
+		}
+	}
+}
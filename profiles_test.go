@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProfiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []Option
+		batcher    batcherConfig
+		sizeLimit  int
+		validation bool
+	}{
+		{
+			"high throughput", []Option{ProfileHighThroughput()},
+			batcherConfig{batchTimeout: 5 * time.Second, maxQueueSize: 16384, maxExportBatchSize: 2048}, 64 * 1024, false,
+		},
+		{
+			"low latency", []Option{ProfileLowLatency()},
+			batcherConfig{batchTimeout: 200 * time.Millisecond, exportTimeout: 5 * time.Second, maxExportBatchSize: 128}, 0, false,
+		},
+		{
+			"debug", []Option{ProfileDebug()},
+			batcherConfig{batchTimeout: 100 * time.Millisecond}, 0, true,
+		},
+		{
+			"override", []Option{ProfileLowLatency(), WithBatchTimeout(time.Second)},
+			batcherConfig{batchTimeout: time.Second, exportTimeout: 5 * time.Second, maxExportBatchSize: 128}, 0, false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := buildOptions(tt.opts)
+			if options.batcher != tt.batcher {
+				t.Errorf("batcher = %+v, want %+v", options.batcher, tt.batcher)
+			}
+			if options.spanSizeLimit != tt.sizeLimit {
+				t.Errorf("span size limit = %d, want %d", options.spanSizeLimit, tt.sizeLimit)
+			}
+			if options.attributeValidation != tt.validation {
+				t.Errorf("attribute validation = %v, want %v", options.attributeValidation, tt.validation)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// WithPropagationHeaderAliases sets alternate names for propagation headers, for
+// environments where proxies mangle the standard ones. aliases maps a standard
+// header name (e.g. "traceparent") to its alternate name (e.g.
+// "x-cdn-traceparent").
+//
+// On extraction the alternate header is used if the standard one is missing. On
+// injection both the standard and the alternate headers are set.
+func WithPropagationHeaderAliases(aliases map[string]string) Option {
+	return func(opts *Options) {
+		if opts.headerAliases == nil {
+			opts.headerAliases = make(map[string]string, len(aliases))
+		}
+		for header, alias := range aliases {
+			opts.headerAliases[strings.ToLower(header)] = strings.ToLower(alias)
+		}
+	}
+}
+
+// WithPropagators adds propagation formats to W3C Trace Context and Baggage, e.g.
+// for legacy services emitting B3 or Jaeger headers. All formats are injected.
+// On extraction the W3C Trace Context wins if the request has several formats.
+//
+//	tracer.WithPropagators(tracer.B3MultiPropagator(), tracer.JaegerPropagator())
+func WithPropagators(propagators ...propagation.TextMapPropagator) Option {
+	return func(opts *Options) {
+		opts.propagators = append(opts.propagators, propagators...)
+	}
+}
+
+// B3SinglePropagator returns the B3 propagator injecting the single "b3" header.
+// Both single and multi header formats are extracted.
+func B3SinglePropagator() propagation.TextMapPropagator {
+	return b3.New(b3.WithInjectEncoding(b3.B3SingleHeader))
+}
+
+// B3MultiPropagator returns the B3 propagator injecting the "X-B3-*" headers.
+// Both single and multi header formats are extracted.
+func B3MultiPropagator() propagation.TextMapPropagator {
+	return b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader))
+}
+
+// JaegerPropagator returns the propagator of the Jaeger "uber-trace-id" header.
+func JaegerPropagator() propagation.TextMapPropagator {
+	return jaeger.Jaeger{}
+}
+
+// newPropagator returns the propagator installed by Init: W3C Trace Context and
+// Baggage, plus the formats added by WithPropagators.
+func newPropagator(options Options) propagation.TextMapPropagator {
+	// Extracted contexts override earlier ones, so the W3C Trace Context goes last.
+	propagators := slices.Concat(
+		options.propagators,
+		[]propagation.TextMapPropagator{propagation.TraceContext{}, propagation.Baggage{}},
+	)
+	if options.requestIDHeader != "" {
+		propagators = append(propagators, requestIDPropagator{header: options.requestIDHeader})
+	}
+
+	var propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(propagators...)
+	if len(options.headerAliases) > 0 {
+		propagator = aliasPropagator{next: propagator, aliases: options.headerAliases}
+	}
+	return propagator
+}
+
+// aliasPropagator supports alternate header names on top of the next propagator.
+type aliasPropagator struct {
+	next    propagation.TextMapPropagator
+	aliases map[string]string
+}
+
+var _ propagation.TextMapPropagator = aliasPropagator{}
+
+func (p aliasPropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	p.next.Inject(ctx, aliasCarrier{TextMapCarrier: carrier, aliases: p.aliases})
+}
+
+func (p aliasPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return p.next.Extract(ctx, aliasCarrier{TextMapCarrier: carrier, aliases: p.aliases})
+}
+
+func (p aliasPropagator) Fields() []string {
+	fields := p.next.Fields()
+	for _, field := range p.next.Fields() {
+		if alias, ok := p.aliases[field]; ok {
+			fields = append(fields, alias)
+		}
+	}
+	return fields
+}
+
+// aliasCarrier reads from the alternate header if the standard one is missing,
+// and writes both.
+type aliasCarrier struct {
+	propagation.TextMapCarrier
+
+	aliases map[string]string
+}
+
+func (c aliasCarrier) Get(key string) string {
+	if value := c.TextMapCarrier.Get(key); value != "" {
+		return value
+	}
+	if alias, ok := c.aliases[key]; ok {
+		return c.TextMapCarrier.Get(alias)
+	}
+	return ""
+}
+
+func (c aliasCarrier) Set(key, value string) {
+	c.TextMapCarrier.Set(key, value)
+	if alias, ok := c.aliases[key]; ok {
+		c.TextMapCarrier.Set(alias, value)
+	}
+}
@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+const traceparent = "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01"
+
+func TestPropagationHeaderAliases(t *testing.T) {
+	p := newPropagator(buildOptions([]Option{
+		WithPropagationHeaderAliases(map[string]string{"Traceparent": "X-CDN-Traceparent"}),
+	}))
+	other := "00-1112131415161718191a1b1c1d1e1f20-1112131415161718-01"
+
+	tests := []struct {
+		name    string
+		carrier propagation.MapCarrier
+		want    string // trace ID
+	}{
+		{"standard", propagation.MapCarrier{"traceparent": traceparent}, "0102030405060708090a0b0c0d0e0f10"},
+		{"alias", propagation.MapCarrier{"x-cdn-traceparent": traceparent}, "0102030405060708090a0b0c0d0e0f10"},
+		{"standard wins", propagation.MapCarrier{"traceparent": traceparent, "x-cdn-traceparent": other}, "0102030405060708090a0b0c0d0e0f10"},
+		{"none", propagation.MapCarrier{}, "00000000000000000000000000000000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := p.Extract(context.Background(), tt.carrier)
+			if got := trace.SpanContextFromContext(ctx).TraceID().String(); got != tt.want {
+				t.Errorf("trace ID = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	ctx := p.Extract(context.Background(), propagation.MapCarrier{"traceparent": traceparent})
+	injected := propagation.MapCarrier{}
+	p.Inject(ctx, injected)
+	if injected["traceparent"] == "" || injected["traceparent"] != injected["x-cdn-traceparent"] {
+		t.Errorf("injected = %v, want both traceparent and its alias", injected)
+	}
+	if !slices.Contains(p.Fields(), "x-cdn-traceparent") {
+		t.Errorf("Fields() = %v, want the alias", p.Fields())
+	}
+}
+
+// TestInstancePropagator checks that the integrations propagate with the
+// propagator of the tracer, not the OpenTelemetry global one.
+func TestPropagators(t *testing.T) {
+	const (
+		traceID = "0102030405060708090a0b0c0d0e0f10"
+		spanID  = "0102030405060708"
+	)
+	p := newPropagator(buildOptions([]Option{WithPropagators(B3MultiPropagator(), JaegerPropagator())}))
+
+	tests := []struct {
+		name    string
+		carrier propagation.MapCarrier
+		want    string // trace ID
+	}{
+		{"b3 single", propagation.MapCarrier{"b3": traceID + "-" + spanID + "-1"}, traceID},
+		{"b3 multi", propagation.MapCarrier{"x-b3-traceid": traceID, "x-b3-spanid": spanID, "x-b3-sampled": "1"}, traceID},
+		{"jaeger", propagation.MapCarrier{"uber-trace-id": traceID + ":" + spanID + ":0:1"}, traceID},
+		{"w3c wins", propagation.MapCarrier{
+			"traceparent":   "00-1112131415161718191a1b1c1d1e1f20-1112131415161718-01",
+			"uber-trace-id": traceID + ":" + spanID + ":0:1",
+		}, "1112131415161718191a1b1c1d1e1f20"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := p.Extract(context.Background(), tt.carrier)
+			if got := trace.SpanContextFromContext(ctx).TraceID().String(); got != tt.want {
+				t.Errorf("trace ID = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	carrier := propagation.MapCarrier{}
+	p.Inject(p.Extract(context.Background(), propagation.MapCarrier{"traceparent": traceparent}), carrier)
+	for _, header := range []string{"traceparent", "x-b3-traceid", "x-b3-spanid", "uber-trace-id"} {
+		if carrier.Get(header) == "" {
+			t.Errorf("%s not injected: %v", header, carrier)
+		}
+	}
+	if carrier.Get("b3") != "" {
+		t.Errorf("b3 single header injected by the multi header propagator: %v", carrier)
+	}
+}
+
+func TestInstancePropagator(t *testing.T) {
+	global := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	t.Cleanup(func() { otel.SetTextMapPropagator(global) })
+	remote := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+	t.Run("middleware", func(t *testing.T) {
+		initTestTracer(t)
+
+		var got trace.TraceID
+		handler := Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			got = trace.SpanContextFromContext(r.Context()).TraceID()
+		}))
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("traceparent", traceparent)
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+
+		if got != remote {
+			t.Errorf("trace ID = %s, want %s", got, remote)
+		}
+	})
+
+	t.Run("transport", func(t *testing.T) {
+		initTestTracer(t)
+
+		var got string
+		server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			got = r.Header.Get("traceparent")
+		}))
+		defer server.Close()
+
+		ctx, span := StartSpan(context.Background(), "request")
+		defer span.End()
+		r, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := (&http.Client{Transport: Transport(nil)}).Do(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if got == "" {
+			t.Error("no traceparent header")
+		}
+	})
+
+	t.Run("grpc server", func(t *testing.T) {
+		initTestTracer(t)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", traceparent))
+		ctx, span := startServerSpan(ctx, "/svc/Method")
+		span.End()
+
+		if got := trace.SpanContextFromContext(ctx).TraceID(); got != remote {
+			t.Errorf("trace ID = %s, want %s", got, remote)
+		}
+	})
+
+	t.Run("grpc client", func(t *testing.T) {
+		initTestTracer(t)
+
+		ctx, span := startClientSpan(context.Background(), "/svc/Method")
+		span.End()
+
+		md, _ := metadata.FromOutgoingContext(ctx)
+		if len(md.Get("traceparent")) == 0 {
+			t.Error("no traceparent metadata")
+		}
+	})
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/metric"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// WithQueueTelemetry tracks the occupancy of the batch processor queue, which
+// the SDK doesn't expose: spans waiting for export, including the batch being
+// exported, and spans dropped because the queue is full. The stats are returned
+// by Tracer.ExportQueue and, with WithMetrics, reported as the
+// "tracer.export.queue.size", "tracer.export.queue.capacity" and
+// "tracer.export.queue.dropped" metrics. It's ignored with WithCLIMode and
+// WithCriticalLane.
+func WithQueueTelemetry() Option {
+	return func(opts *Options) {
+		opts.queueTelemetry = true
+	}
+}
+
+// QueueStats is the batch processor queue occupancy.
+type QueueStats struct {
+	// Size is the number of spans waiting for export.
+	Size int64
+	// Capacity is the max number of spans waiting for export.
+	Capacity int64
+	// Dropped is the number of spans dropped because the queue was full.
+	Dropped int64
+}
+
+// ExportQueue returns the queue stats of the default tracer (see Init).
+func ExportQueue() QueueStats {
+	return current().ExportQueue()
+}
+
+// ExportQueue returns the queue stats, zero if WithQueueTelemetry wasn't set.
+func (t *Tracer) ExportQueue() QueueStats {
+	if t.queue == nil {
+		return QueueStats{}
+	}
+	return t.queue.stats()
+}
+
+// exportQueue tracks spans passed to the batch processor until their export
+// returns. It admits spans itself, so the batch processor never drops them and
+// the dropped count is exact: the batch being exported counts against the
+// capacity, so the batch processor queue holds less.
+type exportQueue struct {
+	capacity int64
+	size     atomic.Int64
+	dropped  atomic.Int64
+}
+
+func newExportQueue(options Options) *exportQueue {
+	return &exportQueue{capacity: int64(batchSnapshot(options).MaxQueueSize)}
+}
+
+func (q *exportQueue) stats() QueueStats {
+	return QueueStats{Size: q.size.Load(), Capacity: q.capacity, Dropped: q.dropped.Load()}
+}
+
+// register reports the stats as metrics of the meter.
+func (q *exportQueue) register(meter metric.Meter) error {
+	size, err := meter.Int64ObservableGauge("tracer.export.queue.size",
+		metric.WithDescription("Spans waiting for export."), metric.WithUnit("{span}"))
+	if err != nil {
+		return fmt.Errorf("failed to create queue size gauge: %w", err)
+	}
+	capacity, err := meter.Int64ObservableGauge("tracer.export.queue.capacity",
+		metric.WithDescription("Max spans waiting for export."), metric.WithUnit("{span}"))
+	if err != nil {
+		return fmt.Errorf("failed to create queue capacity gauge: %w", err)
+	}
+	dropped, err := meter.Int64ObservableCounter("tracer.export.queue.dropped",
+		metric.WithDescription("Spans dropped because the export queue was full."), metric.WithUnit("{span}"))
+	if err != nil {
+		return fmt.Errorf("failed to create queue dropped counter: %w", err)
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stats := q.stats()
+		o.ObserveInt64(size, stats.Size)
+		o.ObserveInt64(capacity, stats.Capacity)
+		o.ObserveInt64(dropped, stats.Dropped)
+		return nil
+	}, size, capacity, dropped)
+	if err != nil {
+		return fmt.Errorf("failed to register queue metrics: %w", err)
+	}
+	return nil
+}
+
+// queueProcessor admits spans to the next (batch) processor while the queue
+// has room.
+type queueProcessor struct {
+	tracesdk.SpanProcessor
+
+	queue *exportQueue
+}
+
+func (p queueProcessor) OnEnd(s tracesdk.ReadOnlySpan) {
+	if !s.SpanContext().IsSampled() {
+		return
+	}
+	if p.queue.size.Add(1) > p.queue.capacity {
+		p.queue.size.Add(-1)
+		p.queue.dropped.Add(1)
+		return
+	}
+	p.SpanProcessor.OnEnd(s)
+}
+
+// queueExporter removes exported spans from the queue once the export returns.
+type queueExporter struct {
+	tracesdk.SpanExporter
+
+	queue *exportQueue
+}
+
+func (e queueExporter) ExportSpans(ctx context.Context, spans []tracesdk.ReadOnlySpan) error {
+	defer e.queue.size.Add(-int64(len(spans)))
+	return e.SpanExporter.ExportSpans(ctx, spans) //nolint:wrapcheck
+}
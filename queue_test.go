@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestQueueProcessor(t *testing.T) {
+	sampled := tracetest.SpanStub{SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1}, SpanID: trace.SpanID{1}, TraceFlags: trace.FlagsSampled,
+	})}.Snapshot()
+	unsampled := tracetest.SpanStub{SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2},
+	})}.Snapshot()
+
+	tests := []struct {
+		name     string
+		capacity int64
+		queued   int64 // spans already in the queue
+		spans    []tracesdk.ReadOnlySpan
+		admitted int
+		dropped  int64
+	}{
+		{"empty", 2, 0, []tracesdk.ReadOnlySpan{sampled, sampled}, 2, 0},
+		{"full", 2, 0, []tracesdk.ReadOnlySpan{sampled, sampled, sampled}, 2, 1},
+		{"exporting", 2, 1, []tracesdk.ReadOnlySpan{sampled, sampled}, 1, 1},
+		{"unsampled", 1, 0, []tracesdk.ReadOnlySpan{unsampled, sampled}, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			queue := &exportQueue{capacity: tt.capacity}
+			queue.size.Store(tt.queued)
+			p := queueProcessor{SpanProcessor: recorder, queue: queue}
+			for _, s := range tt.spans {
+				p.OnEnd(s)
+			}
+
+			if admitted := len(recorder.Ended()); admitted != tt.admitted {
+				t.Errorf("admitted = %d, want %d", admitted, tt.admitted)
+			}
+			if stats := queue.stats(); stats.Size != tt.queued+int64(tt.admitted) || stats.Dropped != tt.dropped {
+				t.Errorf("stats = %+v, want size %d, dropped %d", stats, tt.queued+int64(tt.admitted), tt.dropped)
+			}
+		})
+	}
+}
+
+func TestQueueExporter(t *testing.T) {
+	queue := &exportQueue{capacity: 4}
+	queue.size.Store(3)
+	var exporting int64
+	e := queueExporter{SpanExporter: exportFunc(func(context.Context, []tracesdk.ReadOnlySpan) error {
+		exporting = queue.size.Load()
+		return nil
+	}), queue: queue}
+
+	if err := e.ExportSpans(context.Background(), make([]tracesdk.ReadOnlySpan, 2)); err != nil {
+		t.Fatal(err)
+	}
+	if exporting != 3 {
+		t.Errorf("size during export = %d, want 3", exporting)
+	}
+	if size := queue.size.Load(); size != 1 {
+		t.Errorf("size after export = %d, want 1", size)
+	}
+}
+
+func TestQueueMetrics(t *testing.T) {
+	reader := metricsdk.NewManualReader()
+	queue := &exportQueue{capacity: 4}
+	queue.size.Store(3)
+	queue.dropped.Store(2)
+	if err := queue.register(metricsdk.NewMeterProvider(metricsdk.WithReader(reader)).Meter("")); err != nil {
+		t.Fatal(err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Gauge[int64]:
+			got[m.Name] = data.DataPoints[0].Value
+		case metricdata.Sum[int64]:
+			got[m.Name] = data.DataPoints[0].Value
+		}
+	}
+	want := map[string]int64{
+		"tracer.export.queue.size":     3,
+		"tracer.export.queue.capacity": 4,
+		"tracer.export.queue.dropped":  2,
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("metrics = %v, want %v", got, want)
+	}
+}
+
+// exportFunc is the span exporter function.
+type exportFunc func(ctx context.Context, spans []tracesdk.ReadOnlySpan) error
+
+func (f exportFunc) ExportSpans(ctx context.Context, spans []tracesdk.ReadOnlySpan) error {
+	return f(ctx, spans)
+}
+
+func (exportFunc) Shutdown(context.Context) error { return nil }
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: MIT
+
+// Package redistracer traces go-redis commands with a client hook:
+//
+//	rdb := redis.NewClient(&redis.Options{Addr: addr})
+//	rdb.AddHook(redistracer.NewHook())
+//
+// Commands get client spans named after the command ("redis.get", "redis.set"),
+// pipelines and transactions the "redis.pipeline" span, new connections the
+// "redis.dial" span. Spans are tagged with "db.system", "db.operation.name",
+// "db.redis.key_count" and, for pipelines, "db.redis.pipeline_size", and ended
+// with the Span.End error semantics; redis.Nil isn't an error. Command arguments
+// aren't recorded, as they may carry data. Spans are started only within a
+// traced context, so pool maintenance doesn't make root spans.
+package redistracer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+)
+
+// NewHook returns the hook tracing the commands of the client.
+func NewHook() redis.Hook {
+	return hook{}
+}
+
+type hook struct{}
+
+func (hook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (_ net.Conn, err error) {
+		ctx, span := startSpan(ctx, "redis.dial",
+			semconv.NetworkTransportKey.String(network), semconv.ServerAddress(addr))
+		defer endSpan(span, &err)
+
+		return next(ctx, network, addr)
+	}
+}
+
+func (hook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) (err error) {
+		ctx, span := startSpan(ctx, "redis."+cmd.Name(),
+			semconv.DBOperationName(cmd.Name()),
+			attribute.Int("db.redis.key_count", keyCount(cmd)))
+		defer endSpan(span, &err)
+
+		return next(ctx, cmd)
+	}
+}
+
+func (hook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) (err error) {
+		keys := 0
+		for _, cmd := range cmds {
+			keys += keyCount(cmd)
+		}
+		ctx, span := startSpan(ctx, "redis.pipeline",
+			semconv.DBOperationName(pipelineOperation(cmds)),
+			attribute.Int("db.redis.key_count", keys),
+			attribute.Int("db.redis.pipeline_size", len(cmds)))
+		defer endSpan(span, &err)
+
+		return next(ctx, cmds)
+	}
+}
+
+// startSpan starts the client span of the operation, or returns nil if ctx
+// isn't traced.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, tracer.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, nil
+	}
+	attrs = append(attrs, semconv.DBSystemRedis)
+	return tracer.StartClientSpan(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan ends the span (if any) with err. redis.Nil (no such key) isn't an
+// error.
+func endSpan(span tracer.Span, err *error) {
+	if span == nil {
+		return
+	}
+	if errors.Is(*err, redis.Nil) {
+		span.End()
+		return
+	}
+	span.End(err)
+}
+
+// pipelineOperation returns the distinct command names of the pipeline, e.g.
+// "multi get set exec".
+func pipelineOperation(cmds []redis.Cmder) string {
+	names := make([]string, 0, len(cmds))
+	seen := make(map[string]struct{}, len(cmds))
+	for _, cmd := range cmds {
+		if _, ok := seen[cmd.Name()]; !ok {
+			seen[cmd.Name()] = struct{}{}
+			names = append(names, cmd.Name())
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// keyCount returns the number of keys the command accesses. It knows common
+// multi-key and keyless commands, other commands are assumed to have one key
+// (the first argument).
+func keyCount(cmd redis.Cmder) int {
+	args := cmd.Args()
+	switch cmd.Name() {
+	case "mget", "del", "unlink", "exists", "touch", "watch",
+		"sinter", "sunion", "sdiff", "pfcount", "pfmerge":
+		return len(args) - 1
+	case "mset", "msetnx":
+		return (len(args) - 1) / 2 //nolint:mnd
+	case "eval", "evalsha", "eval_ro", "evalsha_ro", "fcall", "fcall_ro":
+		if len(args) > 2 { //nolint:mnd
+			if n, err := strconv.Atoi(toString(args[2])); err == nil {
+				return n
+			}
+		}
+		return 0
+	case "ping", "echo", "hello", "auth", "select", "info", "dbsize", "time",
+		"client", "config", "cluster", "command", "multi", "exec", "discard",
+		"flushdb", "flushall", "script", "function", "publish", "spublish",
+		"subscribe", "psubscribe", "unsubscribe", "punsubscribe", "scan", "keys",
+		"randomkey", "quit", "unwatch", "wait", "memory", "slowlog", "lastsave":
+		return 0
+	}
+	return min(len(args)-1, 1)
+}
+
+// toString returns the command argument as a string.
+func toString(arg any) string {
+	switch v := arg.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: MIT
+
+package redistracer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/codes"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+	"github.com/cdnnow-pro/go-tracer/tracertest"
+)
+
+func cmd(args ...any) redis.Cmder {
+	return redis.NewCmd(context.Background(), args...)
+}
+
+func TestKeyCount(t *testing.T) {
+	tests := []struct {
+		cmd  redis.Cmder
+		want int
+	}{
+		{cmd("get", "k"), 1},
+		{cmd("set", "k", "v", "ex", 10), 1},
+		{cmd("mget", "a", "b", "c"), 3},
+		{cmd("del", "a", "b"), 2},
+		{cmd("mset", "a", 1, "b", 2), 2},
+		{cmd("eval", "return 1", 2, "a", "b", "arg"), 2},
+		{cmd("evalsha", "sha", "1", "a"), 1},
+		{cmd("eval", "return 1"), 0},
+		{cmd("ping"), 0},
+		{cmd("multi"), 0},
+		{cmd("randomcmd"), 0},
+	}
+	for _, tt := range tests {
+		if got := keyCount(tt.cmd); got != tt.want {
+			t.Errorf("keyCount(%v) = %d, want %d", tt.cmd.Args(), got, tt.want)
+		}
+	}
+}
+
+func TestPipelineOperation(t *testing.T) {
+	cmds := []redis.Cmder{cmd("multi"), cmd("get", "a"), cmd("set", "b", 1), cmd("get", "c"), cmd("exec")}
+	if got := pipelineOperation(cmds); got != "multi get set exec" {
+		t.Errorf("pipelineOperation() = %q, want %q", got, "multi get set exec")
+	}
+}
+
+func TestProcessHook(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status codes.Code
+	}{
+		{"ok", nil, codes.Unset},
+		{"nil", redis.Nil, codes.Unset},
+		{"error", errors.New("READONLY"), codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracertest.Init(t)
+
+			process := NewHook().ProcessHook(func(context.Context, redis.Cmder) error { return tt.err })
+			ctx, span := tracer.StartSpan(context.Background(), "request")
+			if err := process(ctx, cmd("mget", "a", "b")); !errors.Is(err, tt.err) {
+				t.Errorf("err = %v, want %v", err, tt.err)
+			}
+			span.End()
+
+			tracertest.AssertSpan(t, "redis.mget").WithTag("db.system", "redis").WithTag("db.operation.name", "mget").
+				WithTag("db.redis.key_count", 2).WithStatus(tt.status)
+		})
+	}
+}
+
+func TestProcessPipelineHook(t *testing.T) {
+	tracertest.Init(t)
+
+	process := NewHook().ProcessPipelineHook(func(context.Context, []redis.Cmder) error { return nil })
+	ctx, span := tracer.StartSpan(context.Background(), "request")
+	if err := process(ctx, []redis.Cmder{cmd("get", "a"), cmd("mget", "b", "c")}); err != nil {
+		t.Fatal(err)
+	}
+	span.End()
+
+	tracertest.AssertSpan(t, "redis.pipeline").WithTag("db.operation.name", "get mget").
+		WithTag("db.redis.key_count", 3).WithTag("db.redis.pipeline_size", 2)
+}
+
+func TestUntracedContext(t *testing.T) {
+	tracertest.Init(t)
+
+	process := NewHook().ProcessHook(func(context.Context, redis.Cmder) error { return nil })
+	if err := process(context.Background(), cmd("ping")); err != nil {
+		t.Fatal(err)
+	}
+	if spans := tracertest.Spans(t); len(spans) != 0 {
+		t.Errorf("spans = %v, want none outside a traced context", spans)
+	}
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const defaultRequestIDHeader = "X-Request-ID"
+
+// WithRequestIDFallback makes the extracted context carry the legacy request ID
+// header (X-Request-ID by default) when there is no trace context in the
+// carrier. A root span started with such context gets the trace ID derived from
+// the request ID, so logs keyed by request ID can be correlated to the trace.
+//
+// Request IDs that are UUIDs or 32 hex digits are used as trace IDs as is,
+// others are hashed. Requests with the same request ID (e.g. retries) share the
+// trace ID, so their spans end up in one trace. Request IDs are honored only
+// from callers trusted by WithInboundTrustPolicy (Middleware, server
+// interceptors).
+func WithRequestIDFallback(header ...string) Option {
+	return func(opts *Options) {
+		opts.requestIDHeader = defaultRequestIDHeader
+		if len(header) > 0 {
+			opts.requestIDHeader = header[0]
+		}
+	}
+}
+
+type requestIDKey struct{}
+
+// requestIDPropagator extracts the request ID if there is no valid span context
+// extracted by the previous propagators.
+type requestIDPropagator struct {
+	header string
+}
+
+var _ propagation.TextMapPropagator = requestIDPropagator{}
+
+func (requestIDPropagator) Inject(context.Context, propagation.TextMapCarrier) {}
+
+func (p requestIDPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	if requestID := carrier.Get(p.header); requestID != "" {
+		return context.WithValue(ctx, requestIDKey{}, requestID)
+	}
+	return ctx
+}
+
+func (requestIDPropagator) Fields() []string {
+	return nil
+}
+
+// withoutRequestID returns ctx without the extracted request ID, so root spans
+// started with it get random trace IDs.
+func withoutRequestID(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(requestIDKey{}).(string); !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, nil)
+}
+
+// requestIDGenerator derives trace IDs of root spans from the extracted request
+// ID, all other IDs are random.
+type requestIDGenerator struct{}
+
+var _ tracesdk.IDGenerator = requestIDGenerator{}
+
+func (g requestIDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	var traceID trace.TraceID
+	if requestID, ok := ctx.Value(requestIDKey{}).(string); ok {
+		traceID = traceIDFromRequestID(requestID)
+	} else {
+		for !traceID.IsValid() {
+			_, _ = rand.Read(traceID[:])
+		}
+	}
+
+	return traceID, g.NewSpanID(ctx, traceID)
+}
+
+func (requestIDGenerator) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	var spanID trace.SpanID
+	for !spanID.IsValid() {
+		_, _ = rand.Read(spanID[:])
+	}
+	return spanID
+}
+
+func traceIDFromRequestID(requestID string) trace.TraceID {
+	var traceID trace.TraceID
+
+	raw := strings.ReplaceAll(requestID, "-", "")
+	if hex.DecodedLen(len(raw)) == len(traceID) {
+		if _, err := hex.Decode(traceID[:], []byte(raw)); err == nil && traceID.IsValid() {
+			return traceID
+		}
+	}
+
+	sum := sha256.Sum256([]byte(requestID))
+	copy(traceID[:], sum[:])
+
+	return traceID
+}
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTraceIDFromRequestID(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		want      string // empty if hashed
+	}{
+		{"hex", "4bf92f3577b34da6a3ce929d0e0e4736", "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"uuid", "4bf92f35-77b3-4da6-a3ce-929d0e0e4736", "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"too long", strings.Repeat("ab", 17), ""},
+		{"much too long", strings.Repeat("a", 1000), ""},
+		{"too short", "abcd", ""},
+		{"odd length", strings.Repeat("a", 31), ""},
+		{"invalid hex", strings.Repeat("z", 32), ""},
+		{"zero", strings.Repeat("0", 32), ""},
+		{"free form", "req-42", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := traceIDFromRequestID(tt.requestID)
+			if !got.IsValid() {
+				t.Fatalf("trace ID %s is invalid", got)
+			}
+			if tt.want != "" && got.String() != tt.want {
+				t.Errorf("trace ID = %s, want %s", got, tt.want)
+			}
+			if again := traceIDFromRequestID(tt.requestID); again != got {
+				t.Errorf("trace ID isn't stable: %s, then %s", got, again)
+			}
+		})
+	}
+}
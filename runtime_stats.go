@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"runtime/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	heapInUseMetric  = "/memory/classes/heap/objects:bytes"
+	goroutinesMetric = "/sched/goroutines:goroutines"
+)
+
+// runtimeStats is a snapshot of the runtime counters attached to root spans.
+type runtimeStats struct {
+	heapInUse  int64
+	goroutines int64
+}
+
+// readRuntimeStats samples runtime/metrics, which unlike runtime.ReadMemStats
+// doesn't stop the world.
+func readRuntimeStats() *runtimeStats {
+	samples := []metrics.Sample{
+		{Name: heapInUseMetric},
+		{Name: goroutinesMetric},
+	}
+	metrics.Read(samples)
+
+	return &runtimeStats{
+		heapInUse:  sampleValue(samples[0]),
+		goroutines: sampleValue(samples[1]),
+	}
+}
+
+func sampleValue(s metrics.Sample) int64 {
+	if s.Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return int64(s.Value.Uint64()) //nolint:gosec
+}
+
+// attributes returns the stats at start and the deltas between start and end.
+func (start *runtimeStats) attributes(end *runtimeStats) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int64("runtime.heap_inuse.start", start.heapInUse),
+		attribute.Int64("runtime.heap_inuse.delta", end.heapInUse-start.heapInUse),
+		attribute.Int64("runtime.goroutines.start", start.goroutines),
+		attribute.Int64("runtime.goroutines.delta", end.goroutines-start.goroutines),
+	}
+}
+
+// isLocalRoot reports whether a span started with ctx has no local parent.
+func isLocalRoot(ctx context.Context) bool {
+	parent := trace.SpanContextFromContext(ctx)
+	return !parent.IsValid() || parent.IsRemote()
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRuntimeStats(t *testing.T) {
+	remote := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1}, SpanID: trace.SpanID{1}, TraceFlags: trace.FlagsSampled, Remote: true,
+	}))
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		ctx     func(ctx context.Context) context.Context
+		tracked bool
+	}{
+		{"root", []Option{WithRuntimeStats()}, func(ctx context.Context) context.Context { return ctx }, true},
+		{"remote parent", []Option{WithRuntimeStats()}, func(context.Context) context.Context { return remote }, true},
+		{"child", []Option{WithRuntimeStats()}, func(ctx context.Context) context.Context {
+			ctx, _ = StartSpan(ctx, "parent")
+			return ctx
+		}, false},
+		{"disabled", nil, func(ctx context.Context) context.Context { return ctx }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t, tt.opts...)
+
+			_, span := StartSpan(tt.ctx(context.Background()), "span")
+			span.End()
+
+			s := recorder.Ended()[0]
+			for _, key := range []string{
+				"runtime.heap_inuse.start", "runtime.heap_inuse.delta",
+				"runtime.goroutines.start", "runtime.goroutines.delta",
+			} {
+				if _, ok := spanAttribute(s, attribute.Key(key)); ok != tt.tracked {
+					t.Errorf("%s recorded = %v, want %v", key, ok, tt.tracked)
+				}
+			}
+			if v, _ := spanAttribute(s, "runtime.goroutines.start"); tt.tracked && v.AsInt64() <= 0 {
+				t.Errorf("runtime.goroutines.start = %d, want positive", v.AsInt64())
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// WithSampleRatio samples root spans by the trace ID ratio, child spans follow
+// the parent sampling decision.
+func WithSampleRatio(ratio float64) Option {
+	return WithSampler(tracesdk.ParentBased(tracesdk.TraceIDRatioBased(ratio)))
+}
+
+// WithAlwaysSample samples all spans, regardless of the parent decision.
+func WithAlwaysSample() Option {
+	return WithSampler(tracesdk.AlwaysSample())
+}
+
+// WithNeverSample samples no spans, except the ones forced by ForceSampling.
+func WithNeverSample() Option {
+	return WithSampler(tracesdk.NeverSample())
+}
+
+// WithSampler sets the custom sampler. By default root spans are sampled and
+// child spans follow the parent sampling decision.
+func WithSampler(sampler tracesdk.Sampler) Option {
+	return func(opts *Options) {
+		opts.sampler = sampler
+	}
+}
+
+type forceSamplingKey struct{}
+
+// ForceSampling returns the context in which root spans are always sampled,
+// regardless of the configured sampler.
+func ForceSampling(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceSamplingKey{}, true)
+}
+
+func isSamplingForced(ctx context.Context) bool {
+	forced, _ := ctx.Value(forceSamplingKey{}).(bool)
+	return forced
+}
+
+// newSampler returns the sampler installed by Init.
+func newSampler(options Options) tracesdk.Sampler {
+	sampler := options.sampler
+	if sampler == nil {
+		sampler = tracesdk.ParentBased(tracesdk.AlwaysSample())
+	}
+	if options.traceStateTiers != nil {
+		sampler = newTierSampler(sampler, options.traceStateTiers)
+	}
+	if options.syntheticSampling != nil {
+		sampler = newSyntheticSampler(sampler, *options.syntheticSampling)
+	}
+	return forceSampler{next: sampler}
+}
+
+// forceSampler samples spans started with the context returned by
+// ForceSampling, other spans are sampled by the next sampler.
+type forceSampler struct {
+	next tracesdk.Sampler
+}
+
+var _ tracesdk.Sampler = forceSampler{}
+
+func (s forceSampler) ShouldSample(p tracesdk.SamplingParameters) tracesdk.SamplingResult {
+	if isSamplingForced(p.ParentContext) {
+		return tracesdk.AlwaysSample().ShouldSample(p)
+	}
+	return s.next.ShouldSample(p)
+}
+
+func (s forceSampler) Description() string {
+	return "ForceSampler{" + s.next.Description() + "}"
+}
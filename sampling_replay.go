@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// replayRecordLen is the length of the sampling replay record:
+//
+//	<trace ID> <S|D> <time RFC 3339> <reason>\n
+//
+// The reason is padded with spaces.
+const replayRecordLen = 80
+
+// Sampling decision reasons.
+const (
+	replayReasonForced       = "forced"
+	replayReasonSynthetic    = "synthetic"
+	replayReasonRemoteParent = "remote-parent"
+	replayReasonSampler      = "sampler"
+)
+
+// WithSamplingReplay records sampling decisions of local root spans to the ring
+// file at path holding the last records decisions, so during incident analysis
+// it can be found whether the request was sampled and why not:
+//
+//	grep <trace ID> sampling.replay
+//
+// Records are fixed-width lines "<trace ID> <S|D> <time> <reason>", where S is
+// sampled, D is dropped, and the reason is "forced" (see ForceSampling),
+// "synthetic" (see WithSyntheticSampling), "remote-parent" (the span continues
+// the remote trace) or "sampler" (the configured sampler). The file is
+// overwritten from the start by each Init.
+func WithSamplingReplay(path string, records int) Option {
+	return func(opts *Options) {
+		opts.samplingReplay = &samplingReplayOptions{path: path, records: records}
+	}
+}
+
+type samplingReplayOptions struct {
+	path    string
+	records int
+}
+
+// samplingReplay is the ring file of sampling decisions.
+type samplingReplay struct {
+	mu      sync.Mutex
+	f       *os.File
+	records int64
+	next    int64
+}
+
+func openSamplingReplay(options *samplingReplayOptions) (*samplingReplay, error) {
+	if options.records <= 0 {
+		return nil, fmt.Errorf("invalid sampling replay size %d", options.records)
+	}
+
+	f, err := os.OpenFile(options.path, os.O_RDWR|os.O_CREATE, 0o644) //nolint:gosec,mnd
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sampling replay file: %w", err)
+	}
+	if err := f.Truncate(0); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to truncate sampling replay file: %w", err)
+	}
+
+	return &samplingReplay{f: f, records: int64(options.records)}, nil
+}
+
+func (r *samplingReplay) record(traceID trace.TraceID, sampled bool, reason string) {
+	decision := 'D'
+	if sampled {
+		decision = 'S'
+	}
+	line := fmt.Sprintf("%s %c %s %s", traceID, decision, time.Now().UTC().Format(time.RFC3339), reason)
+	line = fmt.Sprintf("%-*.*s\n", replayRecordLen-1, replayRecordLen-1, line)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Errors are ignored: the replay is best effort and must not affect tracing.
+	_, _ = r.f.WriteAt([]byte(line), r.next*replayRecordLen)
+	r.next = (r.next + 1) % r.records
+}
+
+func (r *samplingReplay) close() error {
+	if err := r.f.Close(); err != nil {
+		return fmt.Errorf("failed to close sampling replay file: %w", err)
+	}
+	return nil
+}
+
+// replaySampler records decisions of the next sampler for local root spans.
+type replaySampler struct {
+	next      tracesdk.Sampler
+	replay    *samplingReplay
+	synthetic bool
+}
+
+var _ tracesdk.Sampler = replaySampler{}
+
+func (s replaySampler) ShouldSample(p tracesdk.SamplingParameters) tracesdk.SamplingResult {
+	result := s.next.ShouldSample(p)
+
+	parent := trace.SpanContextFromContext(p.ParentContext)
+	if parent.IsValid() && !parent.IsRemote() {
+		return result
+	}
+
+	var reason string
+	switch {
+	case isSamplingForced(p.ParentContext):
+		reason = replayReasonForced
+	case parent.IsValid():
+		reason = replayReasonRemoteParent
+	case s.synthetic && IsSynthetic(p.ParentContext):
+		reason = replayReasonSynthetic
+	default:
+		reason = replayReasonSampler
+	}
+	s.replay.record(p.TraceID, result.Decision == tracesdk.RecordAndSample, reason)
+
+	return result
+}
+
+func (s replaySampler) Description() string {
+	return s.next.Description()
+}
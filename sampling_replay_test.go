@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSamplingReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sampling.replay")
+	tr, err := New(context.Background(), "app", "1.0.0", WithExporter(tracetest.NewNoopExporter()),
+		WithNeverSample(), WithSamplingReplay(path, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+	var traceIDs []string
+	for _, ctx := range []context.Context{
+		context.Background(),
+		ForceSampling(context.Background()),
+		remote,
+		context.Background(), // overwrites the first record
+	} {
+		ctx, root := tr.StartSpan(ctx, "root")
+		_, child := tr.StartSpan(ctx, "child") // not recorded
+		child.End()
+		root.End()
+		traceIDs = append(traceIDs, root.TraceId())
+	}
+	if err := tr.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 3*replayRecordLen {
+		t.Fatalf("file size = %d, want %d", len(data), 3*replayRecordLen)
+	}
+	want := []struct {
+		traceID, decision, reason string
+	}{
+		{traceIDs[3], "D", replayReasonSampler},
+		{traceIDs[1], "S", replayReasonForced},
+		{traceIDs[2], "D", replayReasonRemoteParent},
+	}
+	for i, w := range want {
+		fields := strings.Fields(string(data[i*replayRecordLen : (i+1)*replayRecordLen]))
+		if len(fields) != 4 || fields[0] != w.traceID || fields[1] != w.decision || fields[3] != w.reason {
+			t.Errorf("record %d = %q, want %s %s <time> %s", i, fields, w.traceID, w.decision, w.reason)
+		}
+	}
+}
+
+func TestSamplingReplayInvalidSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sampling.replay")
+	if _, err := New(context.Background(), "app", "1.0.0", WithExporter(tracetest.NewNoopExporter()), WithSamplingReplay(path, 0)); err == nil {
+		t.Error("New() with the empty sampling replay succeeded")
+	}
+}
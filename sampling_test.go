@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSamplerOptions(t *testing.T) {
+	remote := func(flags trace.TraceFlags) context.Context {
+		return trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{1},
+			SpanID:     trace.SpanID{1},
+			TraceFlags: flags,
+			Remote:     true,
+		}))
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		// Sampled root, child of the sampled parent and child of the
+		// unsampled parent.
+		root, sampledParent, unsampledParent bool
+	}{
+		{"default", nil, true, true, false},
+		{"always", []Option{WithAlwaysSample()}, true, true, true},
+		{"never", []Option{WithNeverSample()}, false, false, false},
+		{"ratio 0", []Option{WithSampleRatio(0)}, false, true, false},
+		{"ratio 1", []Option{WithSampleRatio(1)}, true, true, false},
+		{"custom", []Option{WithSampler(tracesdk.ParentBased(tracesdk.NeverSample()))}, false, true, false},
+		{"last wins", []Option{WithNeverSample(), WithAlwaysSample()}, true, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampler := newSampler(buildOptions(tt.opts))
+			for _, c := range []struct {
+				parent string
+				ctx    context.Context
+				want   bool
+			}{
+				{"none", context.Background(), tt.root},
+				{"sampled", remote(trace.FlagsSampled), tt.sampledParent},
+				{"unsampled", remote(0), tt.unsampledParent},
+			} {
+				result := sampler.ShouldSample(tracesdk.SamplingParameters{ParentContext: c.ctx, TraceID: trace.TraceID{1}, Name: "op"})
+				if sampled := result.Decision == tracesdk.RecordAndSample; sampled != c.want {
+					t.Errorf("sampled with %s parent = %v, want %v", c.parent, sampled, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestForceSampling(t *testing.T) {
+	recorder := initTestTracer(t, WithNeverSample())
+
+	_, span := StartSpan(context.Background(), "unsampled")
+	span.End()
+	ctx, span := StartSpan(ForceSampling(context.Background()), "forced")
+	_, child := StartSpan(ctx, "child")
+	child.End()
+	span.End()
+
+	var names []string
+	for _, s := range recorder.Ended() {
+		names = append(names, s.Name())
+	}
+	if len(names) != 2 || names[0] != "child" || names[1] != "forced" {
+		t.Errorf("sampled spans = %v, want [child forced]", names)
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	serverlessBatchTimeout  = 100 * time.Millisecond
+	serverlessExportTimeout = 2 * time.Second
+	serverlessFlushTimeout  = 2 * time.Second
+)
+
+// WithServerlessMode tunes the tracer for serverless functions (AWS Lambda,
+// Cloud Functions etc.), which may be frozen right after an invocation: batches
+// are exported almost immediately and the export timeout is shortened.
+//
+// Wrap handlers with WrapLambdaHandler, so spans are flushed before the
+// invocation returns.
+func WithServerlessMode() Option {
+	return func(opts *Options) {
+		opts.serverless = true
+	}
+}
+
+// WrapLambdaHandler wraps the function handler: every invocation gets its own
+// root span (ended with the returned error or the panic, which is passed on) and
+// spans are flushed before the handler returns, even if it panics.
+func WrapLambdaHandler[In, Out any](
+	name string, handler func(context.Context, In) (Out, error),
+) func(context.Context, In) (Out, error) {
+	return func(ctx context.Context, in In) (out Out, err error) {
+		ctx, span := StartSpan(ctx, name, trace.WithSpanKind(trace.SpanKindServer))
+		defer func() {
+			r := recover()
+			if r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+			span.End(&err)
+			flushInvocation(ctx)
+			if r != nil {
+				panic(r)
+			}
+		}()
+
+		return handler(ctx, in)
+	}
+}
+
+// flushInvocation flushes spans of the finished invocation. The flush isn't
+// bound to ctx, since the invocation context may be already canceled.
+func flushInvocation(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serverlessFlushTimeout)
+	defer cancel()
+
+	if err := Flush(ctx); err != nil {
+		otel.Handle(fmt.Errorf("tracer: %w", err))
+	}
+}
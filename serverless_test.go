@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWrapLambdaHandler(t *testing.T) {
+	errHandler := errors.New("handler failed")
+
+	tests := []struct {
+		name    string
+		handler func(context.Context, string) (string, error)
+		panics  bool
+		status  codes.Code
+	}{
+		{"success", func(_ context.Context, in string) (string, error) { return in, nil }, false, codes.Unset},
+		{"error", func(context.Context, string) (string, error) { return "", errHandler }, false, codes.Error},
+		{"panic", func(context.Context, string) (string, error) { panic("boom") }, true, codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			// The batch is exported only when the invocation is flushed.
+			tr, err := New(context.Background(), "fn", "test",
+				WithExporter(exporter), WithServerlessMode(), WithBatchTimeout(time.Hour), WithAlwaysSample())
+			if err != nil {
+				t.Fatal(err)
+			}
+			prev := defaultTracer
+			defaultTracer = tr
+			t.Cleanup(func() {
+				defaultTracer = prev
+				_ = tr.Shutdown(context.Background())
+			})
+
+			func() {
+				defer func() {
+					if r := recover(); (r != nil) != tt.panics {
+						t.Errorf("panic = %v, want panic %v", r, tt.panics)
+					}
+				}()
+				_, _ = WrapLambdaHandler("invoke", tt.handler)(context.Background(), "in")
+			}()
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("exported %d spans before return, want 1", len(spans))
+			}
+			if spans[0].Name != "invoke" || spans[0].SpanKind != trace.SpanKindServer {
+				t.Errorf("span = %s %v, want invoke server span", spans[0].Name, spans[0].SpanKind)
+			}
+			if spans[0].Status.Code != tt.status {
+				t.Errorf("status = %v, want %v", spans[0].Status.Code, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// flushBudgetShare is the share of the closer deadline budget given to the
+// final flush, the rest is left for the shutdown.
+const flushBudgetShare = 0.8
+
+// exportStats counts sampled spans that ended, that were exported and that were
+// filtered out by the processor pipeline, so the closer can report spans
+// abandoned on shutdown.
+type exportStats struct {
+	ended    atomic.Int64
+	exported atomic.Int64
+	filtered atomic.Int64
+}
+
+// abandonedError returns the error reporting spans that were never exported.
+func (st *exportStats) abandonedError() error {
+	ended, exported := st.ended.Load(), st.exported.Load()
+	if abandoned := ended - exported - st.filtered.Load(); abandoned > 0 {
+		return fmt.Errorf("%d of %d spans abandoned on shutdown", abandoned, ended)
+	}
+	return nil
+}
+
+// flushContext returns the context for the final flush, limited to the
+// flushBudgetShare of the ctx deadline budget.
+func flushContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	budget := time.Duration(float64(time.Until(deadline)) * flushBudgetShare)
+	return context.WithTimeout(ctx, budget)
+}
+
+// countingProcessor counts ended sampled spans.
+type countingProcessor struct {
+	stats *exportStats
+}
+
+var _ tracesdk.SpanProcessor = countingProcessor{}
+
+func (countingProcessor) OnStart(context.Context, tracesdk.ReadWriteSpan) {}
+
+func (p countingProcessor) OnEnd(s tracesdk.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() {
+		p.stats.ended.Add(1)
+	}
+}
+
+func (countingProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (countingProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// countingExporter counts successfully exported spans.
+type countingExporter struct {
+	tracesdk.SpanExporter
+
+	stats *exportStats
+}
+
+func (e countingExporter) ExportSpans(ctx context.Context, spans []tracesdk.ReadOnlySpan) error {
+	if err := e.SpanExporter.ExportSpans(ctx, spans); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	var n int64
+	for _, s := range spans {
+		n += spanCount(s)
+	}
+	e.stats.exported.Add(n)
+
+	return nil
+}
+
+// spanCount returns the number of ended spans s represents: more than one for
+// spans collapsed by WithSpanCompression.
+func spanCount(s tracesdk.ReadOnlySpan) int64 {
+	for {
+		switch v := s.(type) {
+		case truncatedSpan:
+			s = v.ReadOnlySpan
+		case encryptedSpan:
+			s = v.ReadOnlySpan
+		case reducedSpan:
+			s = v.ReadOnlySpan
+		case collapsedSpan:
+			return v.count
+		default:
+			return 1
+		}
+	}
+}
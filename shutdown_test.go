@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestShutdownAbandonedSpans(t *testing.T) {
+	tests := []struct {
+		name      string
+		exportErr error
+		want      string // error fragment, none if empty
+	}{
+		{"exported", nil, ""},
+		{"abandoned", errors.New("collector unavailable"), "2 of 2 spans abandoned on shutdown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := exportFunc(func(context.Context, []tracesdk.ReadOnlySpan) error {
+				return tt.exportErr
+			})
+			tr, err := New(context.Background(), "app", "1.0.0", WithExporter(exporter), WithAlwaysSample())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for range 2 {
+				_, span := tr.StartSpan(context.Background(), "op")
+				span.End()
+			}
+
+			err = tr.Shutdown(context.Background())
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Shutdown() = %v, want nil", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Shutdown() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlushContext(t *testing.T) {
+	ctx, cancel := flushContext(context.Background())
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("flush context without deadline has one")
+	}
+	cancel()
+
+	parent, cancelParent := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelParent()
+	ctx, cancel = flushContext(parent)
+	defer cancel()
+
+	deadline, _ := ctx.Deadline()
+	if budget := time.Until(deadline); budget > 8*time.Second || budget < 7*time.Second {
+		t.Errorf("flush budget = %v, want 80%% of 10s", budget)
+	}
+}
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// spanOverheadSize is the estimated size of the span fields other than the
+	// name, attributes, events and links (IDs, timestamps, status, kind).
+	spanOverheadSize = 64
+	// linkSize is the estimated size of a link without attributes.
+	linkSize = 32
+	// eventOverheadSize is the estimated size of an event without the name and
+	// attributes.
+	eventOverheadSize = 16
+	// attributeOverheadSize is the estimated size of an attribute without the key
+	// and value.
+	attributeOverheadSize = 4
+)
+
+// WithSpanSizeLimit estimates the serialized size of each span and, if it
+// exceeds budget bytes, drops attributes and then events that don't fit, tagging
+// the span with "span.truncated"=true. It keeps OTLP batches under collector
+// limits when some spans carry huge attributes.
+func WithSpanSizeLimit(budget int) Option {
+	return func(opts *Options) {
+		opts.spanSizeLimit = budget
+	}
+}
+
+// sizeGuardProcessor truncates spans exceeding the size budget before passing
+// them to the next processor.
+type sizeGuardProcessor struct {
+	next   tracesdk.SpanProcessor
+	budget int
+}
+
+var _ tracesdk.SpanProcessor = sizeGuardProcessor{}
+
+func (p sizeGuardProcessor) OnStart(parent context.Context, s tracesdk.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p sizeGuardProcessor) OnEnd(s tracesdk.ReadOnlySpan) {
+	if estimateSpanSize(s) > p.budget {
+		s = truncateSpan(s, p.budget)
+	}
+	p.next.OnEnd(s)
+}
+
+func (p sizeGuardProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p sizeGuardProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// truncatedSpan is the span with attributes and events dropped.
+type truncatedSpan struct {
+	tracesdk.ReadOnlySpan
+
+	attrs         []attribute.KeyValue
+	events        []tracesdk.Event
+	droppedAttrs  int
+	droppedEvents int
+}
+
+func (s truncatedSpan) Attributes() []attribute.KeyValue {
+	return s.attrs
+}
+
+func (s truncatedSpan) Events() []tracesdk.Event {
+	return s.events
+}
+
+func (s truncatedSpan) DroppedAttributes() int {
+	return s.ReadOnlySpan.DroppedAttributes() + s.droppedAttrs
+}
+
+func (s truncatedSpan) DroppedEvents() int {
+	return s.ReadOnlySpan.DroppedEvents() + s.droppedEvents
+}
+
+// truncateSpan keeps attributes and then events in order if they fit into the
+// rest of budget, skipping those that don't.
+func truncateSpan(s tracesdk.ReadOnlySpan, budget int) truncatedSpan {
+	marker := attribute.Bool("span.truncated", true)
+	size := spanOverheadSize + len(s.Name()) + attributeSize(marker)
+	for _, link := range s.Links() {
+		size += linkSize + attributesSize(link.Attributes)
+	}
+
+	t := truncatedSpan{ReadOnlySpan: s}
+	for _, kv := range s.Attributes() {
+		if kvSize := attributeSize(kv); size+kvSize <= budget {
+			size += kvSize
+			t.attrs = append(t.attrs, kv)
+			continue
+		}
+		t.droppedAttrs++
+	}
+	t.attrs = append(t.attrs, marker)
+
+	for _, event := range s.Events() {
+		if eSize := eventSize(event); size+eSize <= budget {
+			size += eSize
+			t.events = append(t.events, event)
+			continue
+		}
+		t.droppedEvents++
+	}
+
+	return t
+}
+
+func estimateSpanSize(s tracesdk.ReadOnlySpan) int {
+	size := spanOverheadSize + len(s.Name()) + attributesSize(s.Attributes())
+	for _, event := range s.Events() {
+		size += eventSize(event)
+	}
+	for _, link := range s.Links() {
+		size += linkSize + attributesSize(link.Attributes)
+	}
+	return size
+}
+
+func eventSize(event tracesdk.Event) int {
+	return eventOverheadSize + len(event.Name) + attributesSize(event.Attributes)
+}
+
+func attributesSize(attrs []attribute.KeyValue) int {
+	size := 0
+	for _, kv := range attrs {
+		size += attributeSize(kv)
+	}
+	return size
+}
+
+func attributeSize(kv attribute.KeyValue) int {
+	size := attributeOverheadSize + len(kv.Key)
+
+	switch kv.Value.Type() {
+	case attribute.STRING:
+		size += len(kv.Value.AsString())
+	case attribute.STRINGSLICE:
+		for _, v := range kv.Value.AsStringSlice() {
+			size += len(v) + 2 //nolint:mnd
+		}
+	case attribute.BOOL:
+		size++
+	case attribute.BOOLSLICE:
+		size += len(kv.Value.AsBoolSlice())
+	case attribute.INT64SLICE:
+		size += 8 * len(kv.Value.AsInt64Slice()) //nolint:mnd
+	case attribute.FLOAT64SLICE:
+		size += 8 * len(kv.Value.AsFloat64Slice()) //nolint:mnd
+	default:
+		size += 8 //nolint:mnd
+	}
+
+	return size
+}
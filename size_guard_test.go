@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSizeGuardProcessor(t *testing.T) {
+	large := attribute.String("http.request.body", strings.Repeat("x", 1000))
+	route := attribute.String("http.route", "/users/{id}")
+	status := attribute.Int("http.response.status_code", 200)
+	event := tracesdk.Event{Name: "retry"}
+	largeEvent := tracesdk.Event{Name: "dump", Attributes: []attribute.KeyValue{large}}
+
+	tests := []struct {
+		name          string
+		span          tracetest.SpanStub
+		attrs         []attribute.Key
+		events        []string
+		droppedAttrs  int
+		droppedEvents int
+	}{
+		{
+			"fits",
+			tracetest.SpanStub{Name: "op", Attributes: []attribute.KeyValue{route, status}, Events: []tracesdk.Event{event}},
+			[]attribute.Key{route.Key, status.Key}, []string{"retry"}, 0, 0,
+		},
+		{
+			"large attribute first",
+			tracetest.SpanStub{Name: "op", Attributes: []attribute.KeyValue{large, route, status}, Events: []tracesdk.Event{event}},
+			[]attribute.Key{route.Key, status.Key, "span.truncated"}, []string{"retry"}, 1, 0,
+		},
+		{
+			"large event first",
+			tracetest.SpanStub{Name: "op", Attributes: []attribute.KeyValue{route}, Events: []tracesdk.Event{largeEvent, event}},
+			[]attribute.Key{route.Key, "span.truncated"}, []string{"retry"}, 0, 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			p := sizeGuardProcessor{next: recorder, budget: 256}
+			p.OnEnd(tt.span.Snapshot())
+
+			s := recorder.Ended()[0]
+			var attrs []attribute.Key
+			for _, kv := range s.Attributes() {
+				attrs = append(attrs, kv.Key)
+			}
+			var events []string
+			for _, e := range s.Events() {
+				events = append(events, e.Name)
+			}
+			if !slices.Equal(attrs, tt.attrs) {
+				t.Errorf("attributes = %v, want %v", attrs, tt.attrs)
+			}
+			if !slices.Equal(events, tt.events) {
+				t.Errorf("events = %v, want %v", events, tt.events)
+			}
+			if s.DroppedAttributes() != tt.droppedAttrs || s.DroppedEvents() != tt.droppedEvents {
+				t.Errorf("dropped attributes, events = %d, %d, want %d, %d",
+					s.DroppedAttributes(), s.DroppedEvents(), tt.droppedAttrs, tt.droppedEvents)
+			}
+		})
+	}
+}
+
+func TestAttributeSize(t *testing.T) {
+	tests := []struct {
+		kv   attribute.KeyValue
+		want int
+	}{
+		{attribute.String("k", "value"), attributeOverheadSize + 1 + 5},
+		{attribute.StringSlice("k", []string{"a", "bc"}), attributeOverheadSize + 1 + 3 + 4},
+		{attribute.Bool("k", true), attributeOverheadSize + 1 + 1},
+		{attribute.BoolSlice("k", []bool{true, false}), attributeOverheadSize + 1 + 2},
+		{attribute.Int("k", 1), attributeOverheadSize + 1 + 8},
+		{attribute.Float64("k", 1), attributeOverheadSize + 1 + 8},
+		{attribute.Int64Slice("k", []int64{1, 2}), attributeOverheadSize + 1 + 16},
+		{attribute.Float64Slice("k", []float64{1, 2, 3}), attributeOverheadSize + 1 + 24},
+	}
+	for _, tt := range tests {
+		if got := attributeSize(tt.kv); got != tt.want {
+			t.Errorf("attributeSize(%s %v) = %d, want %d", tt.kv.Key, tt.kv.Value.Type(), got, tt.want)
+		}
+	}
+}
+
+func TestSpanSizeLimit(t *testing.T) {
+	var exported []tracesdk.ReadOnlySpan
+	exporter := exportFunc(func(_ context.Context, spans []tracesdk.ReadOnlySpan) error {
+		exported = append(exported, spans...)
+		return nil
+	})
+	tr, err := New(context.Background(), "app", "1.0.0", WithExporter(exporter), WithAlwaysSample(), WithSpanSizeLimit(256))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, small := tr.StartSpan(context.Background(), "small", trace.WithAttributes(attribute.String("k", "v")))
+	small.End()
+	_, large := tr.StartSpan(context.Background(), "large", trace.WithAttributes(attribute.String("k", strings.Repeat("x", 1000))))
+	large.End()
+	if err := tr.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(exported) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(exported))
+	}
+	for _, s := range exported {
+		_, truncated := spanAttribute(s, "span.truncated")
+		if want := s.Name() == "large"; truncated != want {
+			t.Errorf("%s: truncated = %v, want %v", s.Name(), truncated, want)
+		}
+	}
+}
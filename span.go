@@ -6,17 +6,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
-	"go.opentelemetry.io/otel/trace/noop"
-	grpccodes "google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type Span interface {
+	// Tag sets the attribute. Values of unsupported types are handled as set by
+	// WithTagFallback.
 	Tag(key string, value any)
 
 	// IsValid returns if the SpanContext is valid. A valid span context has a valid TraceID and SpanID.
@@ -31,6 +32,13 @@ type Span interface {
 	// TraceId returns the TraceID from the SpanContext as string.
 	TraceId() string
 
+	// SpanContext returns the SpanContext of the span.
+	SpanContext() trace.SpanContext
+
+	// AddLink links the span to the span with the span context, e.g. a batch span
+	// to the requests whose work it aggregates.
+	AddLink(sc trace.SpanContext, attrs ...attribute.KeyValue)
+
 	// SetStatus sets the status of the Span in the form of a code and a
 	// description, provided the status hasn't already been set to a higher
 	// value before (OK > Error > Unset). The description is only included in a
@@ -39,20 +47,81 @@ type Span interface {
 
 	AddEvent(name string, opts ...trace.EventOption)
 
+	// AddWarning adds the "warning" event for the non-fatal anomaly (fallback
+	// used, cache bypassed etc.) with the message, the WARN severity and attrs.
+	// Unlike RecordError and End, it doesn't affect the span status.
+	AddWarning(msg string, attrs ...attribute.KeyValue)
+
+	// AddExternalEvent adds the event at the time measured outside the process
+	// (eBPF probes, NIC hardware timestamps), converted by the clock from
+	// nanoseconds. The raw timestamp is kept as "timestamp.external_ns".
+	AddExternalEvent(name string, clock ExternalClock, ns uint64, attrs ...attribute.KeyValue)
+
 	// RecordError will record err as an exception span event for this span. An
 	// additional call to SetStatus is required if the Status of the Span should
 	// be set to Error, as this method does not change the Span status. If this
 	// span is not being recorded or err is nil then this method does nothing.
+	// Attributes of errors registered with RegisterErrorAttributer are attached
+	// to the event.
 	RecordError(err error)
 
+	// TrackDeadline records the time remaining until the ctx deadline as the
+	// "deadline.remaining_ms" attribute and, at End, adds the "deadline.end" event
+	// with the time remaining at that moment. It does nothing if ctx has no deadline.
+	TrackDeadline(ctx context.Context)
+
+	// SetHTTPStatus tags the span with the HTTP response status code and sets
+	// the status according to the semantic conventions: 5xx responses are errors,
+	// 4xx responses are errors for all spans except the server ones.
+	SetHTTPStatus(code int)
+
+	// SetGRPCStatus tags the span with the gRPC status code of err (OK for nil)
+	// and sets the status according to the semantic conventions: all non-OK
+	// codes are errors for client spans, while for server spans only Unknown,
+	// DeadlineExceeded, Unimplemented, Internal, Unavailable and DataLoss are.
+	SetGRPCStatus(err error)
+
+	// SuppressErrorStatus prevents End from setting the Error status for any
+	// error. The error is recorded as the "expected_error" event instead.
+	SuppressErrorStatus()
+
+	// ExpectError marks err (matched with errors.Is) as expected for this
+	// operation, e.g. not found when probing for existence: End records it as the
+	// "expected_error" event instead of setting the Error status.
+	ExpectError(err error)
+
+	// Count adds delta to the counter of the span (rows scanned, retries, cache
+	// hits), concurrency safe. Counters are set as int64 attributes named name at
+	// End, so loops don't emit an event per iteration.
+	Count(name string, delta int64)
+
+	// SetLocal sets the request-scoped value attached to the span wrapper. The
+	// value isn't exported: it allows instrumentation layers to pass hints (e.g.
+	// the matched route) from middleware to the code ending the span or to span
+	// processors (see SpanLocal) without extra context keys.
+	SetLocal(key, value any)
+
+	// GetLocal returns the value set by SetLocal.
+	GetLocal(key any) (any, bool)
+
+	// SetCancelPolicy overrides the cancel policy set by WithCancelPolicy for this span.
+	SetCancelPolicy(policy CancelPolicy)
+
 	// End completes the Span. The Span is considered complete and ready
 	// to be delivered through the rest of the telemetry pipeline after
 	// this method is called. Therefore, updates to the Span are not allowed
 	// after this method has been called.
 	//
 	// Before the Span completion, End handles the specified errors. Sets the status
-	// with codes.Error if any error is not nil. Except the context.Canceled or
-	// gRPC status grpccodes.Canceled: in such case the "canceled" Event will be added.
+	// with codes.Error if any error is not nil. If there are several distinct
+	// errors, each of them is recorded as a separate exception event. Except the context.Canceled or
+	// gRPC status grpccodes.Canceled: they are handled according to the cancel
+	// policy (see WithCancelPolicy), by default the "canceled" Event will be added.
+	// Errors are handled according to their class if the classifier is set with
+	// WithErrorClassifier.
+	// Along with the Error status, the span is tagged with the "error.fingerprint"
+	// attribute, which is the same for errors of the same type and message that
+	// differ only in variable parts (numbers, IDs, quoted strings).
 	//
 	// Arguments are pointers in order to allow at the beginning of an operation make
 	// defer call with empty error that will be changed later:
@@ -66,33 +135,36 @@ type Span interface {
 	End(errs ...*error)
 }
 
+// warnSeverityNumber is the WARN severity number of the OpenTelemetry log data
+// model.
+const warnSeverityNumber = 13
+
 type span struct {
-	s trace.Span
-}
+	s        trace.Span
+	t        *Tracer
+	spanKind trace.SpanKind
 
-var _ Span = (*span)(nil)
+	runtimeStats *runtimeStats
+	deadline     *time.Time
+	ctxDeadline  time.Time       // set with WithDeadlineDiagnostics
+	timeoutCtx   context.Context //nolint:containedctx
+	timeoutCause error           // cause of timeoutCtx done by its own timeout
+	cancelPolicy *CancelPolicy
 
-func (s *span) Tag(key string, value any) {
-	switch v := value.(type) {
-	case int:
-		s.s.SetAttributes(attribute.Int(key, v))
-	case string:
-		s.s.SetAttributes(attribute.String(key, v))
-	case float64:
-		s.s.SetAttributes(attribute.Float64(key, v))
-	case int64:
-		s.s.SetAttributes(attribute.Int64(key, v))
-	case bool:
-		s.s.SetAttributes(attribute.Bool(key, v))
-	case []string:
-		s.s.SetAttributes(attribute.StringSlice(key, v))
-	case []int:
-		s.s.SetAttributes(attribute.IntSlice(key, v))
-	case fmt.Stringer:
-		s.s.SetAttributes(attribute.Stringer(key, v))
-	}
+	suppressErrorStatus bool
+	expectedErrors      []error
+
+	auditGoroutine uint64
+
+	countersMu sync.Mutex
+	counters   map[string]int64
+
+	localsMu sync.Mutex
+	locals   map[any]any
 }
 
+var _ Span = (*span)(nil)
+
 func (s *span) IsValid() bool {
 	return s.s.SpanContext().IsValid()
 }
@@ -109,6 +181,24 @@ func (s *span) TraceId() string {
 	return s.s.SpanContext().TraceID().String()
 }
 
+func (s *span) SpanContext() trace.SpanContext {
+	return s.s.SpanContext()
+}
+
+func (s *span) AddLink(sc trace.SpanContext, attrs ...attribute.KeyValue) {
+	s.s.AddLink(trace.Link{SpanContext: sc, Attributes: attrs})
+}
+
+// WithLinkTo links the span being started to the spans, e.g. a batch span to the
+// requests whose work it aggregates.
+func WithLinkTo(spans ...Span) trace.SpanStartOption {
+	links := make([]trace.Link, 0, len(spans))
+	for _, s := range spans {
+		links = append(links, trace.Link{SpanContext: s.SpanContext()})
+	}
+	return trace.WithLinks(links...)
+}
+
 func (s *span) SetStatus(code codes.Code, description string) {
 	s.s.SetStatus(code, description)
 }
@@ -117,41 +207,187 @@ func (s *span) AddEvent(name string, opts ...trace.EventOption) {
 	s.s.AddEvent(name, opts...)
 }
 
+func (s *span) AddWarning(msg string, attrs ...attribute.KeyValue) {
+	s.s.AddEvent("warning", trace.WithAttributes(append([]attribute.KeyValue{
+		attribute.String("warning.message", msg),
+		attribute.String("severity", "WARN"),
+		attribute.Int("severity_number", warnSeverityNumber),
+	}, attrs...)...))
+}
+
 func (s *span) RecordError(err error) {
-	s.s.RecordError(err)
+	if err == nil {
+		return
+	}
+	s.s.RecordError(err, trace.WithAttributes(errorAttributes(err)...))
 }
 
 func (s *span) End(errs ...*error) {
+	s.handleErrors(distinctErrors(errs))
+	s.endDeadline()
+	s.endTimeout()
+	s.endCounters()
+	if s.runtimeStats != nil {
+		s.s.SetAttributes(s.runtimeStats.attributes(readRuntimeStats())...)
+	}
+	s.endWithLocals()
+	if s.auditGoroutine != 0 {
+		auditEnd(s.auditGoroutine)
+	}
+}
+
+// kind returns the span kind: the one passed to StartSpan or, for spans from
+// context, the one recorded by the SDK.
+func (s *span) kind() trace.SpanKind {
+	if s.spanKind != trace.SpanKindUnspecified {
+		return s.spanKind
+	}
+	if ro, ok := s.s.(tracesdk.ReadOnlySpan); ok {
+		return ro.SpanKind()
+	}
+	return trace.SpanKindInternal
+}
+
+// handleErrors handles errors passed to End. Multiple errors are recorded as
+// separate exception events, and the status is set from all of them: Error if
+// any of them is a failure, otherwise by the first cancellation error.
+func (s *span) handleErrors(errs []error) {
+	if len(errs) <= 1 {
+		for _, err := range errs {
+			s.handleError(err)
+		}
+		return
+	}
+
+	var (
+		failures, canceled []error
+		ok                 bool
+	)
 	for _, err := range errs {
-		if err != nil && (*err) != nil {
-			s.handleError(*err)
-			break
+		class := s.errorClass(err)
+		if s.handleExpected(err, class) {
+			ok = ok || class == ErrorClassOk
+			continue
 		}
+		s.RecordError(err)
+		if class == ErrorClassDefault && isCanceled(err) && s.effectiveCancelPolicy() != CancelAsError {
+			canceled = append(canceled, err)
+		} else {
+			failures = append(failures, err)
+		}
+	}
+
+	switch {
+	case len(failures) > 0:
+		s.setErrorStatus(errors.Join(failures...))
+	case len(canceled) > 0:
+		s.handleCanceled(canceled[0])
+	case ok:
+		s.s.SetStatus(codes.Ok, "")
 	}
-	s.s.End()
 }
 
 func (s *span) handleError(err error) {
-	if errors.Is(err, context.Canceled) || status.Code(err) == grpccodes.Canceled {
-		s.s.AddEvent("canceled", trace.WithTimestamp(time.Now()))
-	} else {
-		s.s.SetStatus(codes.Error, err.Error())
+	class := s.errorClass(err)
+	if s.handleExpected(err, class) {
+		if class == ErrorClassOk {
+			s.s.SetStatus(codes.Ok, "")
+		}
+		return
+	}
+	if class == ErrorClassError || !s.handleCanceled(err) {
+		s.setErrorStatus(err)
 	}
 }
 
+func (s *span) setErrorStatus(err error) {
+	s.s.SetStatus(codes.Error, err.Error())
+	s.s.SetAttributes(errorFingerprintAttribute(err))
+	s.s.SetAttributes(errorAttributes(err)...)
+}
+
+// distinctErrors returns non-nil errors from errs, skipping the ones of the same
+// type and message as the previous ones.
+func distinctErrors(errs []*error) []error {
+	var (
+		distinct []error
+		seen     map[string]struct{}
+	)
+	for _, err := range errs {
+		if err == nil || *err == nil {
+			continue
+		}
+
+		key := fmt.Sprintf("%T\n%s", *err, *err)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		if seen == nil {
+			seen = make(map[string]struct{}, len(errs))
+		}
+		seen[key] = struct{}{}
+		distinct = append(distinct, *err)
+	}
+	return distinct
+}
+
+// StartSpan starts the span with the default tracer (see Init).
 func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, *span) {
-	span := new(span)
-	if tracer == nil {
-		ctx, span.s = noop.NewTracerProvider().Tracer("noop").Start(ctx, name, opts...)
-	} else {
-		ctx, span.s = tracer.Start(ctx, name, opts...)
+	return current().StartSpan(ctx, name, opts...)
+}
+
+// StartSpan starts the span with the tracer t.
+func (t *Tracer) StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, *span) {
+	return t.startSpan(ctx, t.tracer, name, opts...)
+}
+
+// startSpan starts the span with the OpenTelemetry tracer of t (the one of the
+// instrumentation scope).
+func (t *Tracer) startSpan(
+	ctx context.Context, tracer trace.Tracer, name string, opts ...trace.SpanStartOption,
+) (context.Context, *span) {
+	span := &span{t: t}
+	startConfig := trace.NewSpanStartConfig(opts...)
+	if root := t.cliRoot.Load(); root != nil && !startConfig.NewRoot() && !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = contextWithLocalRoot(trace.ContextWithSpan(ctx, root.s), root)
+	}
+	localRoot := startConfig.NewRoot() || isLocalRoot(ctx)
+	if t.options.runtimeStats && localRoot {
+		span.runtimeStats = readRuntimeStats()
 	}
+	if (t.options.propagationAudit || t.options.orphanSpanStacks) && !t.options.noop {
+		var orphanAttrs []attribute.KeyValue
+		span.auditGoroutine, orphanAttrs = auditStart(ctx, name, t.options)
+		opts = append(opts, trace.WithAttributes(orphanAttrs...))
+	}
+	if attrs := syntheticAttributes(ctx); len(attrs) > 0 {
+		opts = append(opts, trace.WithAttributes(attrs...))
+	}
+	if attrs := incidentAttributes(ctx); len(attrs) > 0 {
+		opts = append(opts, trace.WithAttributes(attrs...))
+	}
+	if t.options.deadlineMargin > 0 {
+		parent, _ := spanFromContext(ctx)
+		if attrs := deadlineDiagnostics(ctx, parent, t.options.deadlineMargin); len(attrs) > 0 {
+			opts = append(opts, trace.WithAttributes(attrs...))
+		}
+		span.ctxDeadline, _ = ctx.Deadline()
+	}
+	ctx, span.s = tracer.Start(ctx, name, opts...)
+	span.spanKind = startConfig.SpanKind()
 
-	return ctx, span
+	if localRoot {
+		ctx = contextWithLocalRoot(ctx, span)
+	}
+	return contextWithSpan(ctx, span), span
 }
 
 func SpanFromContext(ctx context.Context) *span {
-	span := new(span)
+	if span, ok := spanFromContext(ctx); ok {
+		return span
+	}
+
+	span := &span{t: current()}
 	span.s = trace.SpanFromContext(ctx)
 
 	return span
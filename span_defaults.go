@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type spanDefaults struct {
+	name   string
+	prefix bool
+	attrs  []attribute.KeyValue
+}
+
+var (
+	spanDefaultsMu       sync.RWMutex
+	spanDefaultsRegistry []spanDefaults
+)
+
+// RegisterSpanDefaults registers default attributes for spans with the name, e.g.
+// component=auth for spans of an auth library. The name ending with "*" matches
+// all spans with the prefix. Defaults are set when the span starts, unless the
+// span already has the attribute.
+//
+// It's intended to be called from init functions of libraries, so cross-cutting
+// teams can enrich spans they don't start directly.
+func RegisterSpanDefaults(name string, attrs ...attribute.KeyValue) {
+	d := spanDefaults{name: name, attrs: attrs}
+	if prefix, ok := strings.CutSuffix(name, "*"); ok {
+		d.name, d.prefix = prefix, true
+	}
+
+	spanDefaultsMu.Lock()
+	defer spanDefaultsMu.Unlock()
+
+	spanDefaultsRegistry = append(spanDefaultsRegistry, d)
+}
+
+// defaultsProcessor sets attributes registered with RegisterSpanDefaults.
+type defaultsProcessor struct{}
+
+var _ tracesdk.SpanProcessor = defaultsProcessor{}
+
+func (defaultsProcessor) OnStart(_ context.Context, s tracesdk.ReadWriteSpan) {
+	spanDefaultsMu.RLock()
+	defer spanDefaultsMu.RUnlock()
+
+	var existing map[attribute.Key]struct{}
+	for _, d := range spanDefaultsRegistry {
+		if !d.matches(s.Name()) {
+			continue
+		}
+		if existing == nil {
+			existing = make(map[attribute.Key]struct{})
+			for _, kv := range s.Attributes() {
+				existing[kv.Key] = struct{}{}
+			}
+		}
+		for _, kv := range d.attrs {
+			if _, ok := existing[kv.Key]; !ok {
+				s.SetAttributes(kv)
+				existing[kv.Key] = struct{}{}
+			}
+		}
+	}
+}
+
+func (defaultsProcessor) OnEnd(tracesdk.ReadOnlySpan) {}
+
+func (defaultsProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (defaultsProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (d spanDefaults) matches(name string) bool {
+	if d.prefix {
+		return strings.HasPrefix(name, d.name)
+	}
+	return name == d.name
+}
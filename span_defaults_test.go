@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRegisterSpanDefaults(t *testing.T) {
+	prev := spanDefaultsRegistry
+	t.Cleanup(func() { spanDefaultsRegistry = prev })
+	RegisterSpanDefaults("auth.Login", attribute.String("component", "auth"), attribute.Bool("audited", true))
+	RegisterSpanDefaults("cache.*", attribute.String("component", "cache"))
+
+	tests := []struct {
+		name      string
+		attrs     []attribute.KeyValue
+		component string // none if empty
+		audited   bool
+	}{
+		{"auth.Login", nil, "auth", true},
+		{"auth.Logout", nil, "", false},
+		{"cache.Get", nil, "cache", false},
+		{"cache", nil, "", false},
+		{"auth.Login", []attribute.KeyValue{attribute.String("component", "sso")}, "sso", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t)
+
+			_, span := StartSpan(context.Background(), tt.name, trace.WithAttributes(tt.attrs...))
+			span.End()
+
+			s := recorder.Ended()[0]
+			if v, _ := spanAttribute(s, "component"); v.AsString() != tt.component {
+				t.Errorf("component = %q, want %q", v.AsString(), tt.component)
+			}
+			if v, _ := spanAttribute(s, "audited"); v.AsBool() != tt.audited {
+				t.Errorf("audited = %v, want %v", v.AsBool(), tt.audited)
+			}
+		})
+	}
+}
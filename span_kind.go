@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartServerSpan starts the span of handling a synchronous request from a
+// remote client (see StartSpan).
+func StartServerSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, *span) {
+	return StartSpan(ctx, name, append(opts, trace.WithSpanKind(trace.SpanKindServer))...)
+}
+
+// StartClientSpan starts the span of a synchronous request to a remote service,
+// e.g. an HTTP call or a database query (see StartSpan).
+func StartClientSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, *span) {
+	return StartSpan(ctx, name, append(opts, trace.WithSpanKind(trace.SpanKindClient))...)
+}
+
+// StartProducerSpan starts the span of sending a message to a broker or queue
+// for asynchronous processing (see StartSpan).
+func StartProducerSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, *span) {
+	return StartSpan(ctx, name, append(opts, trace.WithSpanKind(trace.SpanKindProducer))...)
+}
+
+// StartConsumerSpan starts the span of processing a message received from a
+// broker or queue (see StartSpan).
+func StartConsumerSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, *span) {
+	return StartSpan(ctx, name, append(opts, trace.WithSpanKind(trace.SpanKindConsumer))...)
+}
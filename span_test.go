@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestEndErrors(t *testing.T) {
+	errA, errB := errors.New("a failed"), errors.New("b failed")
+
+	tests := []struct {
+		name       string
+		errs       []error
+		status     codes.Code
+		exceptions int
+	}{
+		{"none", nil, codes.Unset, 0},
+		{"nil", []error{nil, nil}, codes.Unset, 0},
+		{"single", []error{errA}, codes.Error, 0},
+		{"distinct", []error{errA, nil, errB}, codes.Error, 2},
+		{"duplicates", []error{errA, errors.New("a failed"), errA}, codes.Error, 0},
+		{"failure and cancellation", []error{context.Canceled, errA}, codes.Error, 2},
+		{"cancellations", []error{context.Canceled, fmt.Errorf("query: %w", context.Canceled)}, codes.Unset, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t)
+
+			errs := make([]*error, len(tt.errs))
+			for i := range tt.errs {
+				errs[i] = &tt.errs[i]
+			}
+			_, span := StartSpan(context.Background(), "span")
+			span.End(errs...)
+
+			s := recorder.Ended()[0]
+			if s.Status().Code != tt.status {
+				t.Errorf("status = %v, want %v", s.Status().Code, tt.status)
+			}
+			var exceptions int
+			for _, e := range s.Events() {
+				if e.Name == "exception" {
+					exceptions++
+				}
+			}
+			if exceptions != tt.exceptions {
+				t.Errorf("exception events = %d, want %d", exceptions, tt.exceptions)
+			}
+		})
+	}
+}
+
+func TestLinks(t *testing.T) {
+	recorder := initTestTracer(t)
+
+	_, a := StartSpan(context.Background(), "request.a")
+	_, b := StartSpan(context.Background(), "request.b")
+	_, batch := StartSpan(context.Background(), "batch", WithLinkTo(a, b))
+	batch.AddLink(a.SpanContext(), attribute.String("link.reason", "retry"))
+	batch.End()
+	b.End()
+	a.End()
+
+	links := recorder.Ended()[0].Links()
+	if len(links) != 3 {
+		t.Fatalf("links = %d, want 3", len(links))
+	}
+	for i, want := range []Span{a, b, a} {
+		if !links[i].SpanContext.Equal(want.SpanContext()) {
+			t.Errorf("link %d = %v, want %v", i, links[i].SpanContext.SpanID(), want.SpanContext().SpanID())
+		}
+	}
+	if attrs := links[2].Attributes; len(attrs) != 1 || attrs[0] != attribute.String("link.reason", "retry") {
+		t.Errorf("AddLink attributes = %v, want link.reason=retry", attrs)
+	}
+}
+
+func TestSpanKindHelpers(t *testing.T) {
+	tests := []struct {
+		start func(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, *span)
+		want  trace.SpanKind
+	}{
+		{StartSpan, trace.SpanKindInternal},
+		{StartServerSpan, trace.SpanKindServer},
+		{StartClientSpan, trace.SpanKindClient},
+		{StartProducerSpan, trace.SpanKindProducer},
+		{StartConsumerSpan, trace.SpanKindConsumer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.String(), func(t *testing.T) {
+			recorder := initTestTracer(t)
+
+			_, span := tt.start(context.Background(), "op", trace.WithAttributes(attribute.String("k", "v")))
+			span.End()
+
+			s := recorder.Ended()[0]
+			if s.SpanKind() != tt.want {
+				t.Errorf("span kind = %v, want %v", s.SpanKind(), tt.want)
+			}
+			if _, ok := spanAttribute(s, "k"); !ok {
+				t.Error("start options not applied")
+			}
+		})
+	}
+}
+
+func TestAddWarning(t *testing.T) {
+	recorder := initTestTracer(t)
+
+	_, span := StartSpan(context.Background(), "op")
+	span.AddWarning("cache bypassed", attribute.String("cache", "users"))
+	span.End()
+
+	s := recorder.Ended()[0]
+	if s.Status().Code != codes.Unset {
+		t.Errorf("status = %v, want Unset", s.Status().Code)
+	}
+	if len(s.Events()) != 1 || s.Events()[0].Name != "warning" {
+		t.Fatalf("events = %v, want the warning", s.Events())
+	}
+	want := map[attribute.Key]attribute.Value{
+		"warning.message": attribute.StringValue("cache bypassed"),
+		"severity":        attribute.StringValue("WARN"),
+		"severity_number": attribute.IntValue(13),
+		"cache":           attribute.StringValue("users"),
+	}
+	for _, kv := range s.Events()[0].Attributes {
+		if want[kv.Key] != kv.Value {
+			t.Errorf("%s = %v, want %v", kv.Key, kv.Value.Emit(), want[kv.Key].Emit())
+		}
+		delete(want, kv.Key)
+	}
+	if len(want) > 0 {
+		t.Errorf("missing attributes %v", want)
+	}
+}
+
+func TestCount(t *testing.T) {
+	recorder := initTestTracer(t)
+
+	_, span := StartSpan(context.Background(), "op")
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			span.Count("cache.hits", 1)
+		}()
+	}
+	wg.Wait()
+	span.Count("rows", 5)
+	span.Count("rows", -2)
+	span.End()
+
+	s := recorder.Ended()[0]
+	for key, want := range map[attribute.Key]int64{"cache.hits": 10, "rows": 3} {
+		if v, _ := spanAttribute(s, key); v.AsInt64() != want {
+			t.Errorf("%s = %d, want %d", key, v.AsInt64(), want)
+		}
+	}
+}
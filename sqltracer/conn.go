@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: MIT
+
+package sqltracer
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+)
+
+// conn is the traced connection.
+type conn struct {
+	driver.Conn
+
+	cfg *config
+}
+
+var (
+	_ driver.ConnPrepareContext = (*conn)(nil)
+	_ driver.ConnBeginTx        = (*conn)(nil)
+	_ driver.ExecerContext      = (*conn)(nil)
+	_ driver.QueryerContext     = (*conn)(nil)
+	_ driver.Pinger             = (*conn)(nil)
+	_ driver.SessionResetter    = (*conn)(nil)
+	_ driver.Validator          = (*conn)(nil)
+	_ driver.NamedValueChecker  = (*conn)(nil)
+)
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (_ driver.Stmt, err error) {
+	ctx, span := startSpan(ctx, c.cfg, "sql.prepare", query)
+	defer func() { endSpan(span, err) }()
+
+	var s driver.Stmt
+	if pc, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		s, err = pc.PrepareContext(ctx, query)
+	} else {
+		s, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	return &stmt{Stmt: s, query: query, cfg: c.cfg}, nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (_ driver.Tx, err error) {
+	txCtx := ctx
+	ctx, span := startSpan(ctx, c.cfg, "sql.begin", "")
+	defer func() { endSpan(span, err) }()
+
+	var t driver.Tx
+	if bc, ok := c.Conn.(driver.ConnBeginTx); ok {
+		t, err = bc.BeginTx(ctx, opts)
+	} else {
+		if opts.Isolation != driver.IsolationLevel(0) || opts.ReadOnly {
+			return nil, errors.New("sqltracer: driver doesn't support transaction options")
+		}
+		t, err = c.Conn.Begin() //nolint:staticcheck
+	}
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	return &tx{Tx: t, ctx: txCtx, cfg: c.cfg}, nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (_ driver.Result, err error) {
+	ec, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := startSpan(ctx, c.cfg, "sql.exec", query)
+	defer func() { endSpan(span, err) }()
+
+	if err := tracer.InjectFault(ctx, tracer.FaultSQL); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	return ec.ExecContext(ctx, query, args) //nolint:wrapcheck
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (_ driver.Rows, err error) {
+	qc, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := startSpan(ctx, c.cfg, "sql.query", query)
+	defer func() { endSpan(span, err) }()
+
+	if err := tracer.InjectFault(ctx, tracer.FaultSQL); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	return qc.QueryContext(ctx, query, args) //nolint:wrapcheck
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx) //nolint:wrapcheck
+	}
+	return nil
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx) //nolint:wrapcheck
+	}
+	return nil
+}
+
+func (c *conn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if nvc, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return nvc.CheckNamedValue(nv) //nolint:wrapcheck
+	}
+	return driver.ErrSkip
+}
+
+// stmt is the traced prepared statement.
+type stmt struct {
+	driver.Stmt
+
+	query string
+	cfg   *config
+}
+
+var (
+	_ driver.StmtExecContext   = (*stmt)(nil)
+	_ driver.StmtQueryContext  = (*stmt)(nil)
+	_ driver.NamedValueChecker = (*stmt)(nil)
+)
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (_ driver.Result, err error) {
+	ctx, span := startSpan(ctx, s.cfg, "sql.exec", s.query)
+	defer func() { endSpan(span, err) }()
+
+	if err := tracer.InjectFault(ctx, tracer.FaultSQL); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if ec, ok := s.Stmt.(driver.StmtExecContext); ok {
+		return ec.ExecContext(ctx, args) //nolint:wrapcheck
+	}
+	values, err := namedValues(args)
+	if err != nil {
+		return nil, err
+	}
+	return s.Stmt.Exec(values) //nolint:staticcheck,wrapcheck
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (_ driver.Rows, err error) {
+	ctx, span := startSpan(ctx, s.cfg, "sql.query", s.query)
+	defer func() { endSpan(span, err) }()
+
+	if err := tracer.InjectFault(ctx, tracer.FaultSQL); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if qc, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		return qc.QueryContext(ctx, args) //nolint:wrapcheck
+	}
+	values, err := namedValues(args)
+	if err != nil {
+		return nil, err
+	}
+	return s.Stmt.Query(values) //nolint:staticcheck,wrapcheck
+}
+
+// CheckNamedValue delegates to the statement, including the column converter
+// of drivers that have one, hidden by the wrapper from database/sql.
+func (s *stmt) CheckNamedValue(nv *driver.NamedValue) (err error) {
+	switch st := s.Stmt.(type) {
+	case driver.NamedValueChecker:
+		return st.CheckNamedValue(nv) //nolint:wrapcheck
+	case driver.ColumnConverter: //nolint:staticcheck
+		nv.Value, err = st.ColumnConverter(nv.Ordinal - 1).ConvertValue(nv.Value)
+		return err //nolint:wrapcheck
+	default:
+		return driver.ErrSkip
+	}
+}
+
+// namedValues converts args for drivers without context methods, which don't
+// support named arguments.
+func namedValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("sqltracer: driver doesn't support named arguments")
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
+
+// tx is the traced transaction.
+type tx struct {
+	driver.Tx
+
+	ctx context.Context //nolint:containedctx // of BeginTx, database/sql doesn't pass it to Commit and Rollback
+	cfg *config
+}
+
+func (t *tx) Commit() (err error) {
+	_, span := startSpan(t.ctx, t.cfg, "sql.commit", "")
+	defer func() { endSpan(span, err) }()
+
+	return t.Tx.Commit() //nolint:wrapcheck
+}
+
+func (t *tx) Rollback() (err error) {
+	_, span := startSpan(t.ctx, t.cfg, "sql.rollback", "")
+	defer func() { endSpan(span, err) }()
+
+	return t.Tx.Rollback() //nolint:wrapcheck
+}
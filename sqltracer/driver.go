@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: MIT
+
+package sqltracer
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+)
+
+// startSpan starts the client span of the operation, or returns nil if ctx
+// isn't traced.
+func startSpan(ctx context.Context, cfg *config, name, query string) (context.Context, tracer.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, nil
+	}
+
+	attrs := []attribute.KeyValue{semconv.DBSystemKey.String(cfg.system)}
+	if query != "" {
+		if cfg.sanitize != nil {
+			query = cfg.sanitize(query)
+		}
+		attrs = append(attrs, semconv.DBQueryText(query))
+	}
+	return tracer.StartClientSpan(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan ends the span (if any) with err. driver.ErrSkip isn't an error: the
+// operation is retried by database/sql another way.
+func endSpan(span tracer.Span, err error) {
+	if span == nil {
+		return
+	}
+	if errors.Is(err, driver.ErrSkip) {
+		err = nil
+	}
+	span.End(&err)
+}
+
+// tracedDriver opens traced connections.
+type tracedDriver struct {
+	driver.Driver
+
+	cfg *config
+}
+
+func (d *tracedDriver) Open(name string) (driver.Conn, error) {
+	c, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	return &conn{Conn: c, cfg: d.cfg}, nil
+}
+
+func (d *tracedDriver) OpenConnector(name string) (driver.Connector, error) {
+	if dc, ok := d.Driver.(driver.DriverContext); ok {
+		c, err := dc.OpenConnector(name)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		return &connector{Connector: c, driver: d, cfg: d.cfg}, nil
+	}
+	return dsnConnector{dsn: name, driver: d}, nil
+}
+
+// connector makes traced connections with the driver connector.
+type connector struct {
+	driver.Connector
+
+	driver *tracedDriver
+	cfg    *config
+}
+
+func (c *connector) Connect(ctx context.Context) (_ driver.Conn, err error) {
+	ctx, span := startSpan(ctx, c.cfg, "sql.connect", "")
+	defer func() { endSpan(span, err) }()
+
+	dc, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	return &conn{Conn: dc, cfg: c.cfg}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// dsnConnector makes traced connections with drivers without connectors.
+type dsnConnector struct {
+	dsn    string
+	driver *tracedDriver
+}
+
+func (c dsnConnector) Connect(ctx context.Context) (_ driver.Conn, err error) {
+	_, span := startSpan(ctx, c.driver.cfg, "sql.connect", "")
+	defer func() { endSpan(span, err) }()
+
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: MIT
+
+// Package sqltracer traces database/sql queries, transactions and connections
+// by wrapping the driver:
+//
+//	db, err := sqltracer.Open("pgx", dsn, sqltracer.WithDBSystem("postgresql"))
+//
+// Operations get client spans ("sql.query", "sql.exec", "sql.prepare",
+// "sql.begin", "sql.commit", "sql.rollback", "sql.connect") tagged with
+// "db.system" and "db.query.text", ended with the Span.End error semantics. Spans
+// are started only within a traced context, so pool maintenance doesn't make
+// root spans. Faults of the FaultSQL target are injected into queries (see
+// tracer.WithFaultInjection).
+package sqltracer
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+)
+
+// Option configures the tracing of the driver.
+type Option func(cfg *config)
+
+// WithDBSystem sets the "db.system" attribute, e.g. "postgresql" or "mysql".
+func WithDBSystem(system string) Option {
+	return func(cfg *config) {
+		cfg.system = system
+	}
+}
+
+// WithStatementSanitizer sets the function sanitizing statements before they
+// are recorded as "db.query.text".
+func WithStatementSanitizer(sanitize func(query string) string) Option {
+	return func(cfg *config) {
+		cfg.sanitize = sanitize
+	}
+}
+
+// WithSanitizedStatements replaces string and numeric literals in recorded
+// statements with "?", so they don't leak data (see SanitizeStatement).
+func WithSanitizedStatements() Option {
+	return WithStatementSanitizer(SanitizeStatement)
+}
+
+type config struct {
+	system   string
+	sanitize func(query string) string
+}
+
+func buildConfig(opts []Option) *config {
+	cfg := &config{system: "other_sql"}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// literals are string and numeric literals of SQL statements.
+var literals = regexp.MustCompile(`'(?:[^']|'')*'|\b\d+(?:\.\d+)?\b`)
+
+// SanitizeStatement replaces string and numeric literals of the statement with
+// "?".
+func SanitizeStatement(query string) string {
+	return literals.ReplaceAllString(query, "?")
+}
+
+// Open opens the database like sql.Open, with the driver traced.
+func Open(driverName, dsn string, opts ...Option) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	d := db.Driver()
+	if err := db.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close untraced database: %w", err)
+	}
+
+	cfg := buildConfig(opts)
+	if dc, ok := d.(driver.DriverContext); ok {
+		c, err := dc.OpenConnector(dsn)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		return sql.OpenDB(&connector{Connector: c, driver: &tracedDriver{Driver: d, cfg: cfg}, cfg: cfg}), nil
+	}
+	return sql.OpenDB(dsnConnector{dsn: dsn, driver: &tracedDriver{Driver: d, cfg: cfg}}), nil
+}
+
+// Wrap returns the traced driver, e.g. for sql.Register.
+func Wrap(d driver.Driver, opts ...Option) driver.Driver {
+	return &tracedDriver{Driver: d, cfg: buildConfig(opts)}
+}
+
+// WrapConnector returns the traced connector, for sql.OpenDB.
+func WrapConnector(c driver.Connector, opts ...Option) driver.Connector {
+	cfg := buildConfig(opts)
+	return &connector{Connector: c, driver: &tracedDriver{Driver: c.Driver(), cfg: cfg}, cfg: cfg}
+}
@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: MIT
+
+package sqltracer_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+	"github.com/cdnnow-pro/go-tracer/sqltracer"
+	"github.com/cdnnow-pro/go-tracer/tracertest"
+)
+
+const (
+	// skipQuery is declined by the context methods of the connection with
+	// driver.ErrSkip, so database/sql falls back to the prepared statement.
+	skipQuery = "SELECT 'skip'"
+	// failQuery fails with errQuery.
+	failQuery = "SELECT 'fail'"
+)
+
+var errQuery = errors.New("query failed")
+
+func init() {
+	sql.Register("fake", fakeDriver{})
+	sql.Register("fakelegacy", fakeDriver{legacy: true})
+}
+
+// fakeDriver opens fakeConn, or legacyConn without context methods.
+type fakeDriver struct {
+	legacy bool
+}
+
+func (d fakeDriver) Open(string) (driver.Conn, error) {
+	if d.legacy {
+		return legacyConn{}, nil
+	}
+	return fakeConn{}, nil
+}
+
+// legacyConn is the connection without context methods.
+type legacyConn struct{}
+
+func (legacyConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{query: query}, nil }
+func (legacyConn) Close() error                              { return nil }
+func (legacyConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+
+// fakeConn is the connection with context methods.
+type fakeConn struct {
+	legacyConn
+}
+
+func (fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if err := queryError(query); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if err := queryError(query); err != nil {
+		return nil, err
+	}
+	return &fakeRows{}, nil
+}
+
+func (fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) { return fakeTx{}, nil }
+
+func queryError(query string) error {
+	switch query {
+	case skipQuery:
+		return driver.ErrSkip
+	case failQuery:
+		return errQuery
+	default:
+		return nil
+	}
+}
+
+type fakeStmt struct {
+	query string
+}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	if s.query == failQuery {
+		return nil, errQuery
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	if s.query == failQuery {
+		return nil, errQuery
+	}
+	return &fakeRows{}, nil
+}
+
+// fakeRows is the single row with the single column.
+type fakeRows struct {
+	done bool
+}
+
+func (*fakeRows) Columns() []string { return []string{"n"} }
+func (*fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0], r.done = int64(1), true
+	return nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+func query(ctx context.Context, db *sql.DB, q string) error {
+	rows, err := db.QueryContext(ctx, q, 1)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+func exec(ctx context.Context, db *sql.DB, q string) error {
+	_, err := db.ExecContext(ctx, q, 1)
+	return err
+}
+
+func TestDriver(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver string
+		run    func(ctx context.Context, db *sql.DB) error
+		spans  []string
+		err    bool
+	}{
+		{
+			"query", "fake",
+			func(ctx context.Context, db *sql.DB) error { return query(ctx, db, "SELECT 1") },
+			[]string{"sql.connect", "sql.query"}, false,
+		},
+		{
+			"exec", "fake",
+			func(ctx context.Context, db *sql.DB) error { return exec(ctx, db, "SELECT 1") },
+			[]string{"sql.connect", "sql.exec"}, false,
+		},
+		{
+			"failed query", "fake",
+			func(ctx context.Context, db *sql.DB) error { return query(ctx, db, failQuery) },
+			[]string{"sql.connect", "sql.query"}, true,
+		},
+		{
+			"declined query", "fake",
+			func(ctx context.Context, db *sql.DB) error { return query(ctx, db, skipQuery) },
+			[]string{"sql.connect", "sql.query", "sql.prepare", "sql.query"}, false,
+		},
+		{
+			"declined exec", "fake",
+			func(ctx context.Context, db *sql.DB) error { return exec(ctx, db, skipQuery) },
+			[]string{"sql.connect", "sql.exec", "sql.prepare", "sql.exec"}, false,
+		},
+		{
+			"legacy query", "fakelegacy",
+			func(ctx context.Context, db *sql.DB) error { return query(ctx, db, "SELECT 1") },
+			[]string{"sql.connect", "sql.prepare", "sql.query"}, false,
+		},
+		{
+			"legacy failed exec", "fakelegacy",
+			func(ctx context.Context, db *sql.DB) error { return exec(ctx, db, failQuery) },
+			[]string{"sql.connect", "sql.prepare", "sql.exec"}, true,
+		},
+		{
+			"commit", "fake",
+			func(ctx context.Context, db *sql.DB) error {
+				tx, err := db.BeginTx(ctx, nil)
+				if err != nil {
+					return err
+				}
+				if _, err := tx.ExecContext(ctx, "SELECT 1"); err != nil {
+					return err
+				}
+				return tx.Commit()
+			},
+			[]string{"sql.connect", "sql.begin", "sql.exec", "sql.commit"}, false,
+		},
+		{
+			"rollback", "fakelegacy",
+			func(ctx context.Context, db *sql.DB) error {
+				tx, err := db.BeginTx(ctx, nil)
+				if err != nil {
+					return err
+				}
+				return tx.Rollback()
+			},
+			[]string{"sql.connect", "sql.begin", "sql.rollback"}, false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracertest.Init(t)
+			db, err := sqltracer.Open(tt.driver, "", sqltracer.WithDBSystem("postgresql"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			ctx, span := tracer.StartSpan(context.Background(), "request")
+			err = tt.run(ctx, db)
+			span.End()
+			if (err != nil) != tt.err {
+				t.Fatalf("err = %v, want error %v", err, tt.err)
+			}
+
+			var names []string
+			for _, s := range tracertest.Spans(t) {
+				if s.Name() == "request" {
+					continue
+				}
+				names = append(names, s.Name())
+				if s.Parent().SpanID() != span.SpanContext().SpanID() {
+					t.Errorf("%s isn't the child of the request span", s.Name())
+				}
+				if failed := s.Status().Code == codes.Error; failed && !tt.err {
+					t.Errorf("%s failed: %s", s.Name(), s.Status().Description)
+				}
+			}
+			if !slices.Equal(names, tt.spans) {
+				t.Errorf("spans = %v, want %v", names, tt.spans)
+			}
+			if tt.err {
+				tracertest.AssertSpan(t, tt.spans[len(tt.spans)-1]).WithStatus(codes.Error)
+			}
+		})
+	}
+}
+
+func TestQueryText(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []sqltracer.Option
+		want string
+	}{
+		{"raw", nil, "SELECT * FROM users WHERE name = 'bob' AND age > 42"},
+		{"sanitized", []sqltracer.Option{sqltracer.WithSanitizedStatements()}, "SELECT * FROM users WHERE name = ? AND age > ?"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracertest.Init(t)
+			db, err := sqltracer.Open("fake", "", append(tt.opts, sqltracer.WithDBSystem("postgresql"))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			ctx, span := tracer.StartSpan(context.Background(), "request")
+			err = query(ctx, db, "SELECT * FROM users WHERE name = 'bob' AND age > 42")
+			span.End()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tracertest.AssertSpan(t, "sql.query").WithTag("db.query.text", tt.want).WithTag("db.system", "postgresql")
+		})
+	}
+}
+
+func TestUntracedContext(t *testing.T) {
+	tracertest.Init(t)
+	db, err := sqltracer.Open("fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := query(context.Background(), db, "SELECT 1"); err != nil {
+		t.Fatal(err)
+	}
+	if spans := tracertest.Spans(t); len(spans) != 0 {
+		t.Errorf("got %d spans, want none", len(spans))
+	}
+}
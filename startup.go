@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// WithLazyConnect defers the gRPC collector connection to the first export,
+// instead of connecting in the background at Init.
+//
+// Init never fails because the collector is down: spans are queued, and the
+// exporter reconnects and retries failed exports in the background.
+func WithLazyConnect() Option {
+	return func(opts *Options) {
+		opts.lazyConnect = true
+	}
+}
+
+// WithStartupTimeout makes Init wait up to timeout for the gRPC collector
+// connection, so the first spans aren't delayed by connecting. If the collector
+// isn't reachable in time, Init succeeds anyway, the failure is reported to the
+// OpenTelemetry error handler and the exporter keeps reconnecting in the
+// background. It's ignored with WithLazyConnect.
+func WithStartupTimeout(timeout time.Duration) Option {
+	return func(opts *Options) {
+		opts.startupTimeout = timeout
+	}
+}
+
+// awaitConnection waits up to timeout for conn to become ready. Failures are
+// only reported.
+func awaitConnection(ctx context.Context, conn *grpc.ClientConn, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			otel.Handle(fmt.Errorf("tracer: collector isn't reachable in %s (%s), connecting in background", timeout, state))
+			return
+		}
+	}
+}
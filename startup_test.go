@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+func TestStartupTimeout(t *testing.T) {
+	_, reachable := startTraceCollector(t)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unreachable := lis.Addr().String()
+	_ = lis.Close()
+
+	tests := []struct {
+		name     string
+		endpoint string
+		opts     []Option
+		reported bool
+	}{
+		{"reachable", reachable, nil, false},
+		{"unreachable", unreachable, nil, true},
+		{"lazy", unreachable, []Option{WithLazyConnect()}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				reported []error
+			)
+			prev := otel.GetErrorHandler()
+			otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				reported = append(reported, err)
+			}))
+			t.Cleanup(func() { otel.SetErrorHandler(prev) })
+
+			opts := append([]Option{WithCollectorEndpoint(tt.endpoint), WithStartupTimeout(200 * time.Millisecond)}, tt.opts...)
+			tr, err := New(context.Background(), "app", "1.0.0", opts...)
+			if err != nil {
+				t.Fatalf("New() err = %v, want nil", err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			_ = tr.Shutdown(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			var unreachable bool
+			for _, err := range reported {
+				unreachable = unreachable || strings.HasPrefix(err.Error(), "tracer: collector isn't reachable")
+			}
+			if unreachable != tt.reported {
+				t.Errorf("reported errors = %v, want unreachable reported %v", reported, tt.reported)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	syntheticBaggageKey = "synthetic"
+	syntheticAttribute  = "traffic.synthetic"
+)
+
+// MarkSynthetic returns the context marked as carrying synthetic (shadow, load
+// test) traffic. The mark is the "synthetic=1" baggage member, so it propagates
+// to downstream services; spans started with the marked context are tagged with
+// "traffic.synthetic"=true.
+func MarkSynthetic(ctx context.Context) context.Context {
+	return SetBaggage(ctx, syntheticBaggageKey, "1")
+}
+
+// IsSynthetic reports whether ctx carries synthetic traffic.
+func IsSynthetic(ctx context.Context) bool {
+	return Baggage(ctx, syntheticBaggageKey) == "1"
+}
+
+// WithSyntheticSampling samples root spans of synthetic traffic (see
+// MarkSynthetic) with the ratio instead of the configured sampler, e.g. 0 drops
+// load test traffic entirely.
+func WithSyntheticSampling(ratio float64) Option {
+	return func(opts *Options) {
+		opts.syntheticSampling = &ratio
+	}
+}
+
+// syntheticAttributes returns attributes of the span started with ctx.
+func syntheticAttributes(ctx context.Context) []attribute.KeyValue {
+	if !IsSynthetic(ctx) {
+		return nil
+	}
+	return []attribute.KeyValue{attribute.Bool(syntheticAttribute, true)}
+}
+
+// syntheticSampler samples root spans of synthetic traffic by ratio, other
+// spans are sampled by the next sampler.
+type syntheticSampler struct {
+	next      tracesdk.Sampler
+	synthetic tracesdk.Sampler
+}
+
+var _ tracesdk.Sampler = syntheticSampler{}
+
+func newSyntheticSampler(next tracesdk.Sampler, ratio float64) syntheticSampler {
+	return syntheticSampler{next: next, synthetic: tracesdk.TraceIDRatioBased(ratio)}
+}
+
+func (s syntheticSampler) ShouldSample(p tracesdk.SamplingParameters) tracesdk.SamplingResult {
+	if !trace.SpanContextFromContext(p.ParentContext).IsValid() && IsSynthetic(p.ParentContext) {
+		return s.synthetic.ShouldSample(p)
+	}
+	return s.next.ShouldSample(p)
+}
+
+func (s syntheticSampler) Description() string {
+	return fmt.Sprintf("SyntheticSampler{%s,%s}", s.synthetic.Description(), s.next.Description())
+}
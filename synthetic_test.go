@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSyntheticTraffic(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		synthetic bool
+		sampled   bool
+	}{
+		{"regular", nil, false, true},
+		{"synthetic", nil, true, true},
+		{"regular with synthetic sampling", []Option{WithSyntheticSampling(0)}, false, true},
+		{"synthetic dropped", []Option{WithSyntheticSampling(0)}, true, false},
+		{"synthetic sampled", []Option{WithSyntheticSampling(1)}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t, tt.opts...)
+
+			ctx := context.Background()
+			if tt.synthetic {
+				ctx = MarkSynthetic(ctx)
+			}
+			if IsSynthetic(ctx) != tt.synthetic {
+				t.Errorf("IsSynthetic() = %v, want %v", !tt.synthetic, tt.synthetic)
+			}
+			ctx, root := StartSpan(ctx, "root")
+			_, child := StartSpan(ctx, "child")
+			child.End()
+			root.End()
+
+			spans := recorder.Ended()
+			if sampled := len(spans) == 2; sampled != tt.sampled {
+				t.Fatalf("sampled = %v, want %v", sampled, tt.sampled)
+			}
+			for _, s := range spans {
+				if v, _ := spanAttribute(s, syntheticAttribute); v.AsBool() != tt.synthetic {
+					t.Errorf("%s: %s = %v, want %v", s.Name(), syntheticAttribute, v.AsBool(), tt.synthetic)
+				}
+			}
+		})
+	}
+}
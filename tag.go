@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TagFallback is how Span.Tag handles values of unsupported types.
+type TagFallback int
+
+const (
+	// TagFallbackDrop drops the value.
+	TagFallbackDrop TagFallback = iota
+	// TagFallbackFormat sets the value formatted with fmt "%v".
+	TagFallbackFormat
+	// TagFallbackJSON sets the JSON of the value, or drops it if it can't be
+	// marshaled.
+	TagFallbackJSON
+)
+
+// WithTagFallback sets how Span.Tag handles values of unsupported types. Values
+// are dropped by default.
+func WithTagFallback(fallback TagFallback) Option {
+	return func(opts *Options) {
+		opts.tagFallback = fallback
+	}
+}
+
+// WithDroppedTagHook sets the hook called with values dropped by Span.Tag, e.g.
+// to log them in development.
+func WithDroppedTagHook(hook func(key string, value any)) Option {
+	return func(opts *Options) {
+		opts.droppedTagHook = hook
+	}
+}
+
+func (s *span) Tag(key string, value any) {
+	kv, ok := attributeOf(key, value)
+	if !ok {
+		kv, ok = fallbackAttribute(key, value, s.t.options.tagFallback)
+	}
+	if !ok {
+		if hook := s.t.options.droppedTagHook; hook != nil {
+			hook(key, value)
+		}
+		return
+	}
+	s.s.SetAttributes(kv)
+}
+
+// maxSliceLen is the max number of elements of converted slices, the rest are
+// dropped.
+const maxSliceLen = 128
+
+// attributeOf converts the value of a type supported by Span.Tag to the attribute.
+func attributeOf(key string, value any) (attribute.KeyValue, bool) {
+	v, ok := Convert(value)
+	if !ok {
+		return attribute.KeyValue{}, false
+	}
+	return attribute.KeyValue{Key: attribute.Key(key), Value: v}, true
+}
+
+// Convert converts the value of a type supported by Span.Tag to the attribute
+// value, for integrations setting attributes from arbitrary values. Values are
+// normalized, so hostile ones don't break the export: NaN and infinite floats
+// become strings ("NaN", "+Inf", "-Inf"), invalid UTF-8 sequences in strings are
+// replaced with U+FFFD, slices are truncated to 128 elements.
+//
+//nolint:cyclop,gocyclo // Flat type switch.
+func Convert(value any) (attribute.Value, bool) {
+	switch v := value.(type) {
+	case string:
+		return attribute.StringValue(validString(v)), true
+	case bool:
+		return attribute.BoolValue(v), true
+	case int:
+		return attribute.IntValue(v), true
+	case int8:
+		return attribute.Int64Value(int64(v)), true
+	case int16:
+		return attribute.Int64Value(int64(v)), true
+	case int32:
+		return attribute.Int64Value(int64(v)), true
+	case int64:
+		return attribute.Int64Value(v), true
+	case uint:
+		return uintValue(uint64(v)), true
+	case uint8:
+		return attribute.Int64Value(int64(v)), true
+	case uint16:
+		return attribute.Int64Value(int64(v)), true
+	case uint32:
+		return attribute.Int64Value(int64(v)), true
+	case uint64:
+		return uintValue(v), true
+	case float32:
+		return floatValue(float64(v)), true
+	case float64:
+		return floatValue(v), true
+	case []string:
+		v = truncateSlice(v)
+		valid := make([]string, len(v))
+		for i, s := range v {
+			valid[i] = validString(s)
+		}
+		return attribute.StringSliceValue(valid), true
+	case []bool:
+		return attribute.BoolSliceValue(truncateSlice(v)), true
+	case []int:
+		return attribute.IntSliceValue(truncateSlice(v)), true
+	case []int64:
+		return attribute.Int64SliceValue(truncateSlice(v)), true
+	case []float64:
+		return floatSliceValue(truncateSlice(v)), true
+	case []byte:
+		return attribute.StringValue(base64.StdEncoding.EncodeToString(v)), true
+	case time.Time:
+		return attribute.StringValue(v.Format(time.RFC3339Nano)), true
+	case time.Duration:
+		return attribute.StringValue(v.String()), true
+	case attribute.Value:
+		return normalizeValue(v)
+	case error, fmt.Stringer:
+		// fmt handles panics of methods called on nil pointers.
+		return attribute.StringValue(validString(fmt.Sprint(v))), true
+	default:
+		return attribute.Value{}, false
+	}
+}
+
+// normalizeValue normalizes the attribute value like the values of other types.
+func normalizeValue(v attribute.Value) (attribute.Value, bool) {
+	switch v.Type() {
+	case attribute.STRING:
+		return Convert(v.AsString())
+	case attribute.FLOAT64:
+		return Convert(v.AsFloat64())
+	case attribute.STRINGSLICE:
+		return Convert(v.AsStringSlice())
+	case attribute.BOOLSLICE:
+		return Convert(v.AsBoolSlice())
+	case attribute.INT64SLICE:
+		return Convert(v.AsInt64Slice())
+	case attribute.FLOAT64SLICE:
+		return Convert(v.AsFloat64Slice())
+	case attribute.INVALID:
+		return attribute.Value{}, false
+	default:
+		return v, true
+	}
+}
+
+// uintValue returns the int64 value, or the decimal string one if the value
+// overflows int64.
+func uintValue(v uint64) attribute.Value {
+	if v > math.MaxInt64 {
+		return attribute.StringValue(strconv.FormatUint(v, 10))
+	}
+	return attribute.Int64Value(int64(v))
+}
+
+// floatValue returns the float64 value, or the string one for NaN and
+// infinities, which aren't representable in JSON exports.
+func floatValue(v float64) attribute.Value {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return attribute.StringValue(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return attribute.Float64Value(v)
+}
+
+// floatSliceValue returns the float64 slice value, or the string slice one if
+// there are NaNs or infinities.
+func floatSliceValue(v []float64) attribute.Value {
+	for _, f := range v {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			s := make([]string, len(v))
+			for i, f := range v {
+				s[i] = strconv.FormatFloat(f, 'g', -1, 64)
+			}
+			return attribute.StringSliceValue(s)
+		}
+	}
+	return attribute.Float64SliceValue(v)
+}
+
+func validString(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+	return strings.ToValidUTF8(s, string(utf8.RuneError))
+}
+
+func truncateSlice[T any](v []T) []T {
+	return v[:min(len(v), maxSliceLen)]
+}
+
+func fallbackAttribute(key string, value any, fallback TagFallback) (attribute.KeyValue, bool) {
+	switch fallback {
+	case TagFallbackFormat:
+		return attribute.String(key, validString(fmt.Sprintf("%v", value))), true
+	case TagFallbackJSON:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return attribute.KeyValue{}, false
+		}
+		return attribute.String(key, string(b)), true
+	default:
+		return attribute.KeyValue{}, false
+	}
+}
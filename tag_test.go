@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func FuzzConvert(f *testing.F) {
+	f.Add("ok", 1.5, 3)
+	f.Add("\xff\xfe", math.NaN(), 200)
+	f.Add("", math.Inf(1), 0)
+	f.Add("a\xc3", math.Inf(-1), 129)
+
+	f.Fuzz(func(t *testing.T, s string, x float64, n int) {
+		n = min(max(n, 0), 1024)
+		values := []any{
+			s,
+			x,
+			float32(x),
+			[]byte(s),
+			slices.Repeat([]string{s}, n),
+			slices.Repeat([]float64{x}, n),
+			slices.Repeat([]int{1}, n),
+			slices.Repeat([]bool{true}, n),
+			attribute.StringValue(s),
+			attribute.Float64Value(x),
+			attribute.StringSliceValue(slices.Repeat([]string{s}, n)),
+			attribute.Float64SliceValue(slices.Repeat([]float64{x}, n)),
+			attribute.Int64SliceValue(slices.Repeat([]int64{1}, n)),
+			attribute.BoolSliceValue(slices.Repeat([]bool{true}, n)),
+		}
+		for _, value := range values {
+			v, ok := Convert(value)
+			if !ok {
+				t.Fatalf("Convert(%T) failed", value)
+			}
+			checkNormalized(t, v)
+		}
+	})
+}
+
+func checkNormalized(t *testing.T, v attribute.Value) {
+	t.Helper()
+
+	var strs []string
+	var floats []float64
+	length := -1
+	switch v.Type() {
+	case attribute.STRING:
+		strs = []string{v.AsString()}
+	case attribute.FLOAT64:
+		floats = []float64{v.AsFloat64()}
+	case attribute.STRINGSLICE:
+		strs = v.AsStringSlice()
+		length = len(strs)
+	case attribute.FLOAT64SLICE:
+		floats = v.AsFloat64Slice()
+		length = len(floats)
+	case attribute.INT64SLICE:
+		length = len(v.AsInt64Slice())
+	case attribute.BOOLSLICE:
+		length = len(v.AsBoolSlice())
+	}
+
+	for _, s := range strs {
+		if !utf8.ValidString(s) {
+			t.Errorf("invalid UTF-8 %q", s)
+		}
+	}
+	for _, f := range floats {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			t.Errorf("float %v isn't finite", f)
+		}
+	}
+	if length > maxSliceLen {
+		t.Errorf("slice of %d elements, want at most %d", length, maxSliceLen)
+	}
+}
+
+type point struct {
+	X, Y int
+}
+
+type stringer struct{}
+
+func (stringer) String() string { return "stringer" }
+
+func TestTag(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    any
+		fallback TagFallback
+		want     attribute.Value
+		dropped  bool
+	}{
+		{"string", "v", TagFallbackDrop, attribute.StringValue("v"), false},
+		{"int8", int8(-1), TagFallbackDrop, attribute.Int64Value(-1), false},
+		{"uint32", uint32(7), TagFallbackDrop, attribute.Int64Value(7), false},
+		{"uint64", uint64(math.MaxUint64), TagFallbackDrop, attribute.StringValue("18446744073709551615"), false},
+		{"float32", float32(0.5), TagFallbackDrop, attribute.Float64Value(0.5), false},
+		{"bytes", []byte("hi"), TagFallbackDrop, attribute.StringValue("aGk="), false},
+		{"time", at, TagFallbackDrop, attribute.StringValue("2024-01-02T03:04:05Z"), false},
+		{"duration", 1500 * time.Millisecond, TagFallbackDrop, attribute.StringValue("1.5s"), false},
+		{"error", errors.New("boom"), TagFallbackDrop, attribute.StringValue("boom"), false},
+		{"stringer", stringer{}, TagFallbackDrop, attribute.StringValue("stringer"), false},
+		{"struct dropped", point{1, 2}, TagFallbackDrop, attribute.Value{}, true},
+		{"struct formatted", point{1, 2}, TagFallbackFormat, attribute.StringValue("{1 2}"), false},
+		{"struct JSON", point{1, 2}, TagFallbackJSON, attribute.StringValue(`{"X":1,"Y":2}`), false},
+		{"channel JSON", make(chan int), TagFallbackJSON, attribute.Value{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dropped []string
+			recorder := initTestTracer(t, WithTagFallback(tt.fallback), WithDroppedTagHook(func(key string, _ any) {
+				dropped = append(dropped, key)
+			}))
+
+			_, span := StartSpan(context.Background(), "op")
+			span.Tag("k", tt.value)
+			span.End()
+
+			got, ok := spanAttribute(recorder.Ended()[0], "k")
+			if ok == tt.dropped || got != tt.want {
+				t.Errorf("k = %v (set %v), want %v", got.Emit(), ok, tt.want.Emit())
+			}
+			if wasDropped := len(dropped) == 1 && dropped[0] == "k"; wasDropped != tt.dropped {
+				t.Errorf("dropped tag hook called = %v, want %v", wasDropped, tt.dropped)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  attribute.Value
+		ok    bool
+	}{
+		{"NaN", math.NaN(), attribute.StringValue("NaN"), true},
+		{"+Inf", float32(math.Inf(1)), attribute.StringValue("+Inf"), true},
+		{"invalid UTF-8", "a\xffb", attribute.StringValue("a�b"), true},
+		{"float slice with Inf", []float64{1, math.Inf(-1)}, attribute.StringSliceValue([]string{"1", "-Inf"}), true},
+		{"attribute NaN", attribute.Float64Value(math.NaN()), attribute.StringValue("NaN"), true},
+		{"attribute int", attribute.IntValue(1), attribute.IntValue(1), true},
+		{"invalid attribute", attribute.Value{}, attribute.Value{}, false},
+		{"nil", nil, attribute.Value{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Convert(tt.value)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("Convert() = %v, %v, want %v, %v", got.Emit(), ok, tt.want.Emit(), tt.ok)
+			}
+		})
+	}
+
+	long, _ := Convert(slices.Repeat([]int{1}, 2*maxSliceLen))
+	if n := len(long.AsInt64Slice()); n != maxSliceLen {
+		t.Errorf("converted slice length = %d, want %d", n, maxSliceLen)
+	}
+}
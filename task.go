@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// taskShutdownTimeout limits the flush and shutdown after the task.
+	taskShutdownTimeout = 10 * time.Second
+
+	// taskPanicExitCode is the exit code of panicked tasks, as of Go programs
+	// exiting on panic.
+	taskPanicExitCode = 2
+)
+
+// RunTask runs the migration or one-off job fn within the root span named name,
+// tagged with the executable and its arguments (credentials redacted), and
+// then flushes and shuts down the default tracer, even if fn panics. Returns the
+// exit code: 0 on success, 1 on error and 2 on panic (the panic and its stack
+// are recorded on the span):
+//
+//	if _, err := tracer.Init(ctx, "migrate", version); err != nil {
+//		log.Fatal(err)
+//	}
+//	os.Exit(tracer.RunTask(ctx, "migrate", migrate))
+func RunTask(ctx context.Context, name string, fn func(ctx context.Context) error) (code int) {
+	t := current()
+	ctx, span := t.StartSpan(ctx, name, trace.WithNewRoot(), trace.WithAttributes(
+		semconv.ProcessExecutableName(filepath.Base(os.Args[0])),
+		semconv.ProcessCommandArgs(taskArgs(os.Args)...),
+	))
+
+	var err error
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+			span.s.RecordError(err, trace.WithAttributes(semconv.ExceptionStacktrace(string(debug.Stack()))))
+			code = taskPanicExitCode
+		}
+		span.s.SetAttributes(semconv.ProcessExitCode(code))
+		span.End(&err)
+
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), taskShutdownTimeout)
+		defer cancel()
+		if err := shutdownDefault(shutdownCtx, t); err != nil {
+			otel.Handle(fmt.Errorf("tracer: task %s: %w", name, err))
+		}
+	}()
+
+	if err = fn(ctx); err != nil {
+		return 1
+	}
+	return 0
+}
+
+// credentialFlags are the substrings of flag names whose values are redacted.
+var credentialFlags = []string{"password", "passwd", "pwd", "secret", "token", "key", "dsn", "auth", "credential"}
+
+// taskArgs returns args with values of credential-like flags (--password=x,
+// -token x) and credentials of URL arguments (e.g. DSNs) redacted.
+func taskArgs(args []string) []string {
+	tagged := make([]string, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		tagged[i] = arg
+		if name, _, ok := strings.Cut(arg, "="); ok && isCredentialFlag(name) {
+			tagged[i] = name + "=" + redacted
+			continue
+		}
+		if isCredentialFlag(arg) {
+			// The value is the next argument, unless it's a boolean flag.
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				i++
+				tagged[i] = redacted
+			}
+			continue
+		}
+		if !strings.Contains(arg, "://") {
+			continue
+		}
+		if u, err := url.Parse(arg); err == nil && u.User != nil {
+			tagged[i] = u.Redacted()
+		}
+	}
+	return tagged
+}
+
+// isCredentialFlag reports whether arg is a flag (-name or --name) with the
+// credential-like name.
+func isCredentialFlag(arg string) bool {
+	if !strings.HasPrefix(arg, "-") {
+		return false
+	}
+	name := strings.ToLower(strings.TrimLeft(arg, "-"))
+	for _, credential := range credentialFlags {
+		if strings.Contains(name, credential) {
+			return true
+		}
+	}
+	return false
+}
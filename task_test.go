@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTaskArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"plain", []string{"migrate", "-v", "up"}, []string{"migrate", "-v", "up"}},
+		{"flag=value", []string{"migrate", "--password=secret", "--dsn=host=db"}, []string{"migrate", "--password=REDACTED", "--dsn=REDACTED"}},
+		{"flag value", []string{"migrate", "-token", "abc", "up"}, []string{"migrate", "-token", "REDACTED", "up"}},
+		{"case", []string{"migrate", "--API-Key", "abc"}, []string{"migrate", "--API-Key", "REDACTED"}},
+		{"boolean flag", []string{"migrate", "--no-auth", "--dry-run"}, []string{"migrate", "--no-auth", "--dry-run"}},
+		{"last flag", []string{"migrate", "--secret"}, []string{"migrate", "--secret"}},
+		{"url", []string{"migrate", "postgres://user:pass@db/app"}, []string{"migrate", "postgres://user:xxxxx@db/app"}},
+		{"positional", []string{"migrate", "token"}, []string{"migrate", "token"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := taskArgs(tt.args); !slices.Equal(got, tt.want) {
+				t.Errorf("taskArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunTask(t *testing.T) {
+	tests := []struct {
+		name   string
+		fn     func(ctx context.Context) error
+		code   int
+		status codes.Code
+	}{
+		{"success", func(context.Context) error { return nil }, 0, codes.Unset},
+		{"error", func(context.Context) error { return errors.New("migration failed") }, 1, codes.Error},
+		{"panic", func(context.Context) error { panic("boom") }, 2, codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := initTestTracer(t)
+			parent := trace.ContextWithSpanContext(context.Background(), testSpanContext(t, ""))
+
+			var taskSpan trace.SpanContext
+			code := RunTask(parent, "migrate", func(ctx context.Context) error {
+				taskSpan = trace.SpanContextFromContext(ctx)
+				return tt.fn(ctx)
+			})
+			if code != tt.code {
+				t.Errorf("RunTask() = %d, want %d", code, tt.code)
+			}
+			if defaultTracer != nil {
+				t.Error("default tracer not shut down")
+			}
+
+			s := recorder.Ended()[0]
+			if s.Name() != "migrate" || s.Parent().IsValid() || s.SpanContext().SpanID() != taskSpan.SpanID() {
+				t.Errorf("span = %s with parent %v, want the root migrate span of the task context", s.Name(), s.Parent())
+			}
+			if s.Status().Code != tt.status {
+				t.Errorf("status = %v, want %v", s.Status().Code, tt.status)
+			}
+			if v, _ := spanAttribute(s, semconv.ProcessExitCodeKey); v.AsInt64() != int64(tt.code) {
+				t.Errorf("process.exit.code = %d, want %d", v.AsInt64(), tt.code)
+			}
+			if _, ok := spanAttribute(s, semconv.ProcessCommandArgsKey); !ok {
+				t.Error("process.command_args not recorded")
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"strings"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tierPrefix prefixes the sampling tier in the vendor tracestate value.
+const tierPrefix = "tier:"
+
+// WithTraceStateTiers samples spans by the sampling tier set upstream in the
+// vendor tracestate member (e.g. "cdnnow=tier:gold"), so services keep the
+// upstream sampling class. tiers maps tier names to sampling ratios: 1 samples
+// all spans of the tier, 0 none. The tier is also read from links, so traces
+// rerooted at the trust boundary keep it, and is propagated in the tracestate of
+// sampled spans. Spans without a known tier are sampled by the configured
+// sampler.
+func WithTraceStateTiers(vendor string, tiers map[string]float64) Option {
+	return func(opts *Options) {
+		opts.traceStateTiers = &traceStateTiers{vendor: vendor, tiers: tiers}
+	}
+}
+
+type traceStateTiers struct {
+	vendor string
+	tiers  map[string]float64
+}
+
+func newTierSampler(next tracesdk.Sampler, cfg *traceStateTiers) tierSampler {
+	samplers := make(map[string]tracesdk.Sampler, len(cfg.tiers))
+	for tier, ratio := range cfg.tiers {
+		samplers[tier] = tracesdk.TraceIDRatioBased(ratio)
+	}
+	return tierSampler{next: next, vendor: cfg.vendor, tiers: samplers}
+}
+
+// tierSampler samples spans by the tracestate sampling tier.
+type tierSampler struct {
+	next   tracesdk.Sampler
+	vendor string
+	tiers  map[string]tracesdk.Sampler
+}
+
+var _ tracesdk.Sampler = tierSampler{}
+
+func (s tierSampler) ShouldSample(p tracesdk.SamplingParameters) tracesdk.SamplingResult {
+	value := s.vendorValue(p)
+	sampler, ok := s.tiers[tierOf(value)]
+	if !ok {
+		return s.next.ShouldSample(p)
+	}
+
+	result := sampler.ShouldSample(p)
+	if result.Tracestate.Get(s.vendor) == "" {
+		if state, err := result.Tracestate.Insert(s.vendor, value); err == nil {
+			result.Tracestate = state
+		}
+	}
+	return result
+}
+
+func (s tierSampler) Description() string {
+	return "TierSampler{" + s.vendor + "," + s.next.Description() + "}"
+}
+
+// vendorValue returns the vendor tracestate value of the parent or, if missing,
+// of the first link having it.
+func (s tierSampler) vendorValue(p tracesdk.SamplingParameters) string {
+	if value := trace.SpanContextFromContext(p.ParentContext).TraceState().Get(s.vendor); value != "" {
+		return value
+	}
+	for _, link := range p.Links {
+		if value := link.SpanContext.TraceState().Get(s.vendor); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// tierOf returns the tier of the vendor tracestate value of ";"-separated
+// fields, e.g. "gold" for "tier:gold;region:eu".
+func tierOf(value string) string {
+	for field := range strings.SplitSeq(value, ";") {
+		if tier, ok := strings.CutPrefix(field, tierPrefix); ok {
+			return tier
+		}
+	}
+	return ""
+}
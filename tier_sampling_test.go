@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTierOf(t *testing.T) {
+	tests := []struct {
+		value string
+		tier  string
+	}{
+		{"", ""},
+		{"tier:gold", "gold"},
+		{"region:eu;tier:silver", "silver"},
+		{"region:eu", ""},
+	}
+	for _, tt := range tests {
+		if tier := tierOf(tt.value); tier != tt.tier {
+			t.Errorf("tierOf(%q) = %q, want %q", tt.value, tier, tt.tier)
+		}
+	}
+}
+
+func TestTierSampler(t *testing.T) {
+	s := newTierSampler(tracesdk.AlwaysSample(), &traceStateTiers{
+		vendor: "cdnnow", tiers: map[string]float64{"gold": 1, "bronze": 0},
+	})
+	withState := func(state string) trace.SpanContext {
+		sc := testSpanContext(t, state)
+		return sc.WithTraceFlags(0).WithRemote(true)
+	}
+
+	tests := []struct {
+		name     string
+		parent   trace.SpanContext
+		links    []trace.Link
+		decision tracesdk.SamplingDecision
+		state    string // vendor tracestate value of the result
+	}{
+		{"no tier", trace.SpanContext{}, nil, tracesdk.RecordAndSample, ""},
+		{"unknown tier", withState("cdnnow=tier:tin"), nil, tracesdk.RecordAndSample, "tier:tin"},
+		{"gold", withState("cdnnow=tier:gold"), nil, tracesdk.RecordAndSample, "tier:gold"},
+		{"bronze", withState("cdnnow=tier:bronze"), nil, tracesdk.Drop, "tier:bronze"},
+		{"link", trace.SpanContext{}, []trace.Link{{SpanContext: withState("cdnnow=tier:gold;region:eu")}}, tracesdk.RecordAndSample, "tier:gold;region:eu"},
+		{"parent wins", withState("cdnnow=tier:bronze"), []trace.Link{{SpanContext: withState("cdnnow=tier:gold")}}, tracesdk.Drop, "tier:bronze"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.ShouldSample(tracesdk.SamplingParameters{
+				ParentContext: trace.ContextWithRemoteSpanContext(context.Background(), tt.parent),
+				TraceID:       trace.TraceID{1},
+				Name:          "op",
+				Links:         tt.links,
+			})
+			if result.Decision != tt.decision {
+				t.Errorf("decision = %v, want %v", result.Decision, tt.decision)
+			}
+			if state := result.Tracestate.Get("cdnnow"); state != tt.state {
+				t.Errorf("tracestate cdnnow = %q, want %q", state, tt.state)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTimeout starts a span and derives the context with timeout d from it.
+//
+// The span is tagged with the configured timeout ("timeout.configured_ms") and,
+// at End, with whether the timeout fired ("timeout.fired"). End must be called
+// before the returned cancel function:
+//
+//	ctx, span, cancel := tracer.WithTimeout(ctx, "fetch", time.Second)
+//	defer cancel()
+//	defer span.End(&err)
+func WithTimeout(
+	ctx context.Context, name string, d time.Duration, opts ...trace.SpanStartOption,
+) (context.Context, *span, context.CancelFunc) {
+	ctx, span := StartSpan(ctx, name, opts...)
+	span.s.SetAttributes(attribute.Int64("timeout.configured_ms", d.Milliseconds()))
+
+	// The cause is unique to the call, so timeouts of parent contexts, inherited
+	// by ctx, aren't mistaken for this one.
+	cause := fmt.Errorf("timeout %s fired: %w", d, context.DeadlineExceeded)
+	ctx, cancel := context.WithTimeoutCause(ctx, d, cause)
+	span.timeoutCtx, span.timeoutCause = ctx, cause
+
+	return ctx, span, cancel
+}
+
+// endTimeout tags the span with whether the timeout set by WithTimeout fired.
+func (s *span) endTimeout() {
+	if s.timeoutCtx == nil {
+		return
+	}
+	fired := context.Cause(s.timeoutCtx) == s.timeoutCause //nolint:errorlint // Identity of the cause.
+	s.s.SetAttributes(attribute.Bool("timeout.fired", fired))
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: MIT
+
+package tracer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+	"github.com/cdnnow-pro/go-tracer/tracertest"
+)
+
+func TestWithTimeoutFired(t *testing.T) {
+	tests := []struct {
+		name    string
+		parent  time.Duration // parent context timeout, none if 0
+		timeout time.Duration
+		wait    bool
+		fired   bool
+	}{
+		{"not expired", 0, time.Hour, false, false},
+		{"own timeout", 0, time.Millisecond, true, true},
+		{"parent deadline", time.Millisecond, time.Hour, true, false},
+		{"parent deadline after", time.Hour, time.Millisecond, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracertest.Init(t)
+
+			ctx := context.Background()
+			if tt.parent > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.parent)
+				defer cancel()
+			}
+			ctx, span, cancel := tracer.WithTimeout(ctx, "op", tt.timeout)
+			defer cancel()
+			if tt.wait {
+				<-ctx.Done()
+			}
+			span.End()
+
+			tracertest.AssertSpan(t, "op").WithTag("timeout.fired", tt.fired)
+		})
+	}
+}
+
+func TestWithTimeoutNested(t *testing.T) {
+	tests := []struct {
+		name         string
+		outer, inner time.Duration
+		outerFired   bool
+		innerFired   bool
+	}{
+		{"outer fires", time.Millisecond, time.Hour, true, false},
+		{"inner fires", time.Hour, time.Millisecond, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracertest.Init(t)
+
+			ctx, outer, cancelOuter := tracer.WithTimeout(context.Background(), "outer", tt.outer)
+			defer cancelOuter()
+			ctx, inner, cancelInner := tracer.WithTimeout(ctx, "inner", tt.inner)
+			defer cancelInner()
+			<-ctx.Done()
+			inner.End()
+			outer.End()
+
+			tracertest.AssertSpan(t, "outer").WithTag("timeout.fired", tt.outerFired)
+			tracertest.AssertSpan(t, "inner").WithTag("timeout.fired", tt.innerFired)
+		})
+	}
+}
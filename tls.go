@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// WithTLS makes the exporter connect to the collector over TLS with the config.
+func WithTLS(cfg *tls.Config) Option {
+	return func(opts *Options) {
+		opts.tlsConfig = cfg
+	}
+}
+
+// WithTLSCertFiles makes the exporter connect to the collector over TLS with the
+// CA certificate to verify the collector (system roots if ca is empty) and,
+// for mTLS, the client certificate and key (no client certificate if empty).
+// Files are loaded by Init.
+func WithTLSCertFiles(ca, cert, key string) Option {
+	return func(opts *Options) {
+		opts.tlsFiles = &tlsFiles{ca: ca, cert: cert, key: key}
+	}
+}
+
+type tlsFiles struct {
+	ca, cert, key string
+}
+
+// clientTLSConfig returns the TLS config of the collector connection, nil for
+// the plaintext connection.
+func clientTLSConfig(options Options) (*tls.Config, error) {
+	if options.tlsFiles == nil {
+		return options.tlsConfig, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if options.tlsConfig != nil {
+		cfg = options.tlsConfig.Clone()
+	}
+
+	files := options.tlsFiles
+	if files.ca != "" {
+		pem, err := os.ReadFile(files.ca)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("failed to parse CA certificate")
+		}
+		cfg.RootCAs = pool
+	}
+	if files.cert != "" || files.key != "" {
+		cert, err := tls.LoadX509KeyPair(files.cert, files.key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
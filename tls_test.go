@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCertFiles writes the self-signed certificate and its key to dir.
+func writeCertFiles(t *testing.T, dir string) (cert, key string) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, key = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(key, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestClientTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	cert, key := writeCertFiles(t, dir)
+	invalid := filepath.Join(dir, "invalid.pem")
+	if err := os.WriteFile(invalid, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	base := &tls.Config{ServerName: "collector.internal", MinVersion: tls.VersionTLS13}
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		tls        bool
+		serverName string
+		rootCAs    bool
+		clientCert bool
+		err        bool
+	}{
+		{"plaintext", nil, false, "", false, false, false},
+		{"config", []Option{WithTLS(base)}, true, "collector.internal", false, false, false},
+		{"system roots", []Option{WithTLSCertFiles("", "", "")}, true, "", false, false, false},
+		{"CA", []Option{WithTLSCertFiles(cert, "", "")}, true, "", true, false, false},
+		{"mTLS", []Option{WithTLSCertFiles(cert, cert, key)}, true, "", true, true, false},
+		{"files over config", []Option{WithTLS(base), WithTLSCertFiles(cert, "", "")}, true, "collector.internal", true, false, false},
+		{"missing CA", []Option{WithTLSCertFiles(filepath.Join(dir, "missing.pem"), "", "")}, false, "", false, false, true},
+		{"invalid CA", []Option{WithTLSCertFiles(invalid, "", "")}, false, "", false, false, true},
+		{"missing key", []Option{WithTLSCertFiles("", cert, "")}, false, "", false, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := clientTLSConfig(buildOptions(tt.opts))
+			if (err != nil) != tt.err {
+				t.Fatalf("clientTLSConfig() error = %v, want error %v", err, tt.err)
+			}
+			if (cfg != nil) != tt.tls {
+				t.Fatalf("clientTLSConfig() = %v, want TLS %v", cfg, tt.tls)
+			}
+			if cfg == nil {
+				return
+			}
+			if cfg.ServerName != tt.serverName {
+				t.Errorf("ServerName = %q, want %q", cfg.ServerName, tt.serverName)
+			}
+			if (cfg.RootCAs != nil) != tt.rootCAs {
+				t.Errorf("RootCAs set = %v, want %v", cfg.RootCAs != nil, tt.rootCAs)
+			}
+			if (len(cfg.Certificates) > 0) != tt.clientCert {
+				t.Errorf("client certificate set = %v, want %v", len(cfg.Certificates) > 0, tt.clientCert)
+			}
+		})
+	}
+
+	if base.RootCAs != nil {
+		t.Error("WithTLS config modified")
+	}
+}
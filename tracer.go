@@ -6,9 +6,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log/global"
 	"go.opentelemetry.io/otel/propagation"
+	logsdk "go.opentelemetry.io/otel/sdk/log"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
@@ -16,53 +21,345 @@ import (
 	"go.opentelemetry.io/otel/trace/noop"
 )
 
-var tracer trace.Tracer
+// Tracer is the tracer with its own resource, exporter and options. Use it to
+// run multiple logical services in one binary, otherwise use the package-level
+// functions working with the default tracer made by Init.
+type Tracer struct {
+	tracer   trace.Tracer
+	provider *tracesdk.TracerProvider // nil for noop tracer
+	// loggerProvider is set with WithLogs.
+	loggerProvider *logsdk.LoggerProvider
+	// savedLogger is the slog default logger replaced by Init with
+	// WithDefaultLogger.
+	savedLogger *savedLogger
+	// meterProvider is set with WithMetrics.
+	meterProvider *metricsdk.MeterProvider
+	propagator    propagation.TextMapPropagator
+	options       Options
 
-// Init makes the global tracer and connects to the traces collector (gRPC).
+	// capabilities are probed with WithCollectorProbe.
+	capabilities CollectorCapabilities
+
+	// queue is tracked with WithQueueTelemetry.
+	queue *exportQueue
+
+	// cardinality reduces attributes with WithAdaptiveCardinality.
+	cardinality *cardinalityGuard
+
+	// cliRoot is the root span of the command run in the CLI mode.
+	cliRoot atomic.Pointer[span]
+
+	closer func(context.Context) error
+}
+
+var (
+	// defaultTracer is the tracer used by the package-level functions.
+	defaultTracer *Tracer
+
+	// noopTracer is used by the package-level functions before Init.
+	noopTracer = &Tracer{
+		tracer:     noop.NewTracerProvider().Tracer("noop"),
+		propagator: propagation.TraceContext{},
+		options:    Options{noop: true},
+	}
+)
+
+// current returns the default tracer, or the noop one if Init wasn't called.
+func current() *Tracer {
+	if defaultTracer != nil {
+		return defaultTracer
+	}
+	return noopTracer
+}
+
+// Init makes the default tracer and connects to the traces collector (gRPC or,
+// with WithHTTPExporter, HTTP). The tracer provider and propagator are set as
+// the OpenTelemetry globals.
 //
-// Returns closer that closes connection and shuts down tracer provider.
+// Returns closer that flushes spans (with most of the ctx deadline budget), shuts
+// down tracer provider and closes connection. The closer reports spans that were
+// never exported as an error. After the closer Init can be called again.
 func Init(ctx context.Context, appName, version string, opts ...Option) (func(context.Context) error, error) {
-	if tracer != nil {
+	if defaultTracer != nil {
 		return nil, errors.New("tracer already initialized")
 	}
 
+	t, err := New(ctx, appName, version, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defaultTracer = t
+
+	if t.provider != nil {
+		otel.SetTracerProvider(t.provider)
+		otel.SetTextMapPropagator(t.propagator)
+	}
+	if t.meterProvider != nil {
+		otel.SetMeterProvider(t.meterProvider)
+	}
+	if t.loggerProvider != nil {
+		global.SetLoggerProvider(t.loggerProvider)
+		if t.options.defaultLogger {
+			handler := t.LogHandler()
+			if local := t.options.logs.local; local != nil {
+				handler = teeHandler{local, handler}
+			}
+			t.savedLogger = setDefaultLogger(handler)
+		}
+	}
+
+	return func(ctx context.Context) error {
+		return shutdownDefault(ctx, t)
+	}, nil
+}
+
+// shutdownDefault shuts down the default tracer t and resets it, so Init can be
+// called again.
+func shutdownDefault(ctx context.Context, t *Tracer) error {
+	err := t.Shutdown(ctx)
+	if t.savedLogger != nil {
+		t.savedLogger.restore()
+	}
+	if defaultTracer == t {
+		defaultTracer = nil
+	}
+	return err
+}
+
+// New makes the tracer and connects to the traces collector. Unlike Init, it
+// doesn't touch the default tracer and the OpenTelemetry globals, so any number
+// of tracers can be made. The tracer must be shut down with Tracer.Shutdown.
+func New(ctx context.Context, appName, version string, opts ...Option) (*Tracer, error) {
 	options := buildOptions(opts)
 
 	if options.IsNoop() {
-		tracer = noop.NewTracerProvider().Tracer("")
-		return func(_ context.Context) error {
-			return nil
+		return &Tracer{
+			tracer:     noop.NewTracerProvider().Tracer(""),
+			propagator: newPropagator(options),
+			options:    options,
+			closer: func(_ context.Context) error {
+				return nil
+			},
 		}, nil
 	}
 
-	exporter, closer, err := makeGrpcExporter(ctx, options)
+	if options.encryption != nil && options.encryption.err != nil {
+		return nil, options.encryption.err
+	}
+	if options.pipeline != nil && options.pipeline.err != nil {
+		return nil, options.pipeline.err
+	}
+
+	conn, err := dialCollector(options)
+	if err != nil {
+		return nil, err
+	}
+	if conn != nil && options.startupTimeout > 0 && !options.lazyConnect {
+		awaitConnection(ctx, conn, options.startupTimeout)
+	}
+	var caps CollectorCapabilities
+	if options.collectorProbe > 0 {
+		caps = probeCollector(ctx, conn, options)
+	}
+
+	exporter, err := makeExporter(ctx, options, conn, caps)
 	if err != nil {
+		return nil, errors.Join(err, closeConn(conn))
+	}
+	res := newResource(appName, version, options)
+
+	// fail releases what's made so far.
+	closers := []func(context.Context) error{
+		exporter.Shutdown,
+		func(context.Context) error { return closeConn(conn) },
+	}
+	fail := func(err error) (*Tracer, error) {
+		for _, closer := range slices.Backward(closers) {
+			err = errors.Join(err, closer(ctx))
+		}
 		return nil, err
 	}
 
-	tp := tracesdk.NewTracerProvider(
-		tracesdk.WithBatcher(exporter),
-		tracesdk.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceName(appName),
-			semconv.ServiceVersion(version),
-		)),
+	sampler := newSampler(options)
+	var replay *samplingReplay
+	if options.samplingReplay != nil {
+		replay, err = openSamplingReplay(options.samplingReplay)
+		if err != nil {
+			return fail(err)
+		}
+		closers = append(closers, func(context.Context) error { return replay.close() })
+		sampler = replaySampler{next: sampler, replay: replay, synthetic: options.syntheticSampling != nil}
+	}
+
+	var auditProcessor tracesdk.SpanProcessor
+	if options.auditTrail != nil {
+		auditProcessor, err = newAuditTrailProcessor(ctx, options)
+		if err != nil {
+			return fail(err)
+		}
+		closers = append(closers, auditProcessor.Shutdown)
+	}
+
+	var loggerProvider *logsdk.LoggerProvider
+	if options.logs != nil && options.localExporter == nil {
+		loggerProvider, err = newLoggerProvider(ctx, options, conn, res)
+		if err != nil {
+			return fail(err)
+		}
+		closers = append(closers, loggerProvider.Shutdown)
+	}
+
+	var meterProvider *metricsdk.MeterProvider
+	if options.metrics && options.localExporter == nil {
+		meterProvider, err = newMeterProvider(ctx, options, conn, res)
+		if err != nil {
+			return fail(err)
+		}
+		closers = append(closers, meterProvider.Shutdown)
+	}
+
+	var queue *exportQueue
+	if options.queueTelemetry && !options.cliMode && !options.criticalLane {
+		queue = newExportQueue(options)
+		if meterProvider != nil {
+			if err := queue.register(meterProvider.Meter("")); err != nil {
+				return fail(err)
+			}
+		}
+	}
+
+	var cardinality *cardinalityGuard
+	if options.cardinalityLimit != nil {
+		cardinality = newCardinalityGuard(*options.cardinalityLimit)
+	}
+	pacer := newExportPacer(options.exportPacing)
+	// wrapExporter applies the export options to exporter.
+	wrapExporter := func(exporter tracesdk.SpanExporter) tracesdk.SpanExporter {
+		return pacer.wrap(withClockOffset(exporter, options.clockOffset))
+	}
+	stats := new(exportStats)
+	tpOpts := []tracesdk.TracerProviderOption{
+		tracesdk.WithSpanProcessor(defaultsProcessor{}),
+		tracesdk.WithSampler(sampler),
+		tracesdk.WithResource(res),
+	}
+	for _, processor := range options.spanProcessors {
+		tpOpts = append(tpOpts, tracesdk.WithSpanProcessor(processor))
+	}
+	tpOpts = append(tpOpts,
+		tracesdk.WithSpanProcessor(countingProcessor{stats: stats}),
+		tracesdk.WithSpanProcessor(options.pipeline.wrap(newSpanProcessor(
+			countingExporter{SpanExporter: wrapExporter(exporter), stats: stats}, options, queue, cardinality,
+		), stats)),
 	)
-	otel.SetTracerProvider(tp)
-	otel.SetTextMapPropagator(propagation.TraceContext{})
+	if auditProcessor != nil {
+		tpOpts = append(tpOpts, tracesdk.WithSpanProcessor(options.pipeline.wrap(auditProcessor, nil)))
+	}
+	for _, additional := range options.additionalExporters {
+		tpOpts = append(tpOpts, tracesdk.WithSpanProcessor(
+			options.pipeline.wrap(newSpanProcessor(wrapExporter(additional), options, nil, cardinality), nil),
+		))
+	}
+	if options.durationReport != nil {
+		tpOpts = append(tpOpts, tracesdk.WithSpanProcessor(
+			newDurationReporter(options.durationReport.w, options.durationReport.window),
+		))
+	}
+	if options.attributeValidation {
+		tpOpts = append(tpOpts, tracesdk.WithSpanProcessor(newValidationProcessor()))
+	}
+	if options.requestIDHeader != "" {
+		tpOpts = append(tpOpts, tracesdk.WithIDGenerator(requestIDGenerator{}))
+	}
 
-	tracer = otel.Tracer("")
+	tp := tracesdk.NewTracerProvider(tpOpts...)
+	t := &Tracer{
+		tracer:         tp.Tracer(""),
+		provider:       tp,
+		loggerProvider: loggerProvider,
+		meterProvider:  meterProvider,
+		capabilities:   caps,
+		queue:          queue,
+		cardinality:    cardinality,
+		propagator:     newPropagator(options),
+		options:        options,
+	}
+
+	if options.cliMode {
+		t.startCLIRootSpan(serviceName(appName, options))
+	}
+
+	stopFlushTicker := func() {}
+	if options.flushInterval > 0 {
+		stopFlushTicker = startFlushTicker(tp, options.flushInterval)
+	}
+
+	t.closer = func(ctx context.Context) error {
+		stopFlushTicker()
+		t.endCLIRootSpan()
 
-	return func(ctx context.Context) error {
 		var errs []error
+
+		flushCtx, cancel := flushContext(ctx)
+		if err := tp.ForceFlush(flushCtx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush spans: %w", err))
+		}
+		cancel()
+
 		if err := tp.Shutdown(ctx); err != nil {
 			errs = append(errs, fmt.Errorf("failed to shutdown tracer provider: %w", err))
 		}
-		if closer != nil {
-			if err := closer(); err != nil {
+		if meterProvider != nil {
+			if err := meterProvider.Shutdown(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("failed to shutdown meter provider: %w", err))
+			}
+		}
+		if loggerProvider != nil {
+			if err := loggerProvider.Shutdown(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("failed to shutdown logger provider: %w", err))
+			}
+		}
+		if err := closeConn(conn); err != nil {
+			errs = append(errs, err)
+		}
+		if replay != nil {
+			if err := replay.close(); err != nil {
 				errs = append(errs, err)
 			}
 		}
+		if err := stats.abandonedError(); err != nil {
+			errs = append(errs, err)
+		}
 		return errors.Join(errs...)
-	}, nil
+	}
+
+	return t, nil
+}
+
+// Shutdown flushes spans (with most of the ctx deadline budget), shuts down
+// tracer provider and closes connection. Spans that were never exported are
+// reported as an error.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	if t.closer == nil { // noopTracer
+		return nil
+	}
+	return t.closer(ctx)
+}
+
+// serviceName returns the service name: appName unless overridden by options.
+func serviceName(appName string, options Options) string {
+	if options.serviceName != "" {
+		return options.serviceName
+	}
+	return appName
+}
+
+func newResource(appName, version string, options Options) *resource.Resource {
+	// Later attributes win, so the service name and version can't be overridden.
+	attrs := append(slices.Clone(options.resourceAttributes),
+		semconv.ServiceName(serviceName(appName, options)),
+		semconv.ServiceVersion(version),
+	)
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...)
 }
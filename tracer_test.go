@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+)
+
+// initTestTracer makes the tracer recording spans the default for the test.
+func initTestTracer(t *testing.T, opts ...Option) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	// opts override the defaults, e.g. the sampler.
+	opts = append([]Option{WithExporter(tracetest.NewNoopExporter()), WithSpanProcessor(recorder), WithAlwaysSample()}, opts...)
+	tr, err := New(context.Background(), t.Name(), "test", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := defaultTracer
+	defaultTracer = tr
+	t.Cleanup(func() {
+		defaultTracer = prev
+		_ = tr.Shutdown(context.Background())
+	})
+	return recorder
+}
+
+// spanAttribute returns the value of the span attribute with the key.
+func spanAttribute(s tracesdk.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range s.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestNew(t *testing.T) {
+	exported := make(map[string][]tracesdk.ReadOnlySpan)
+	newTracer := func(name string) *Tracer {
+		exporter := exportFunc(func(_ context.Context, spans []tracesdk.ReadOnlySpan) error {
+			exported[name] = append(exported[name], spans...)
+			return nil
+		})
+		tr, err := New(context.Background(), name, "1.0.0", WithExporter(exporter), WithAlwaysSample())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tr
+	}
+	a, b := newTracer("a"), newTracer("b")
+
+	_, span := a.StartSpan(context.Background(), "a.op")
+	span.End()
+	_, span = b.StartSpan(context.Background(), "b.op")
+	span.End()
+	if ctx, span := StartSpan(context.Background(), "default.op"); span.IsValid() || SpanFromContext(ctx).IsValid() {
+		t.Error("New changed the default tracer")
+	}
+	for _, tr := range []*Tracer{a, b} {
+		if err := tr.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range []string{"a", "b"} {
+		spans := exported[name]
+		if len(spans) != 1 || spans[0].Name() != name+".op" {
+			t.Fatalf("tracer %s exported %d spans, want its own span", name, len(spans))
+		}
+		if service, _ := spans[0].Resource().Set().Value(semconv.ServiceNameKey); service.AsString() != name {
+			t.Errorf("tracer %s: service.name = %q", name, service.AsString())
+		}
+	}
+}
+
+// startAttributeProcessor tags spans on start and records its shutdown.
+type startAttributeProcessor struct {
+	tracesdk.SpanProcessor
+
+	shutdown bool
+}
+
+func (p *startAttributeProcessor) OnStart(_ context.Context, s tracesdk.ReadWriteSpan) {
+	s.SetAttributes(attribute.String("region", "eu"))
+}
+
+func (p *startAttributeProcessor) Shutdown(context.Context) error {
+	p.shutdown = true
+	return nil
+}
+
+func TestSpanProcessor(t *testing.T) {
+	processor := &startAttributeProcessor{SpanProcessor: tracetest.NewSpanRecorder()}
+	var exported []tracesdk.ReadOnlySpan
+	tr, err := New(context.Background(), "app", "1.0.0", WithAlwaysSample(), WithSpanProcessor(processor),
+		WithExporter(exportFunc(func(_ context.Context, spans []tracesdk.ReadOnlySpan) error {
+			exported = append(exported, spans...)
+			return nil
+		})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := tr.StartSpan(context.Background(), "op")
+	span.End()
+	if err := tr.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !processor.shutdown {
+		t.Error("processor not shut down")
+	}
+	if len(exported) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(exported))
+	}
+	if v, _ := spanAttribute(exported[0], "region"); v.AsString() != "eu" {
+		t.Errorf("region = %q, want the processor attribute eu", v.AsString())
+	}
+}
+
+func TestInit(t *testing.T) {
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	opts := []Option{WithExporter(tracetest.NewNoopExporter()), WithAlwaysSample()}
+	closer, err := Init(context.Background(), "app", "1.0.0", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := StartSpan(context.Background(), "op")
+	if !span.IsValid() {
+		t.Error("Init didn't make the default tracer")
+	}
+	span.End()
+	if _, err := Init(context.Background(), "app", "1.0.0", opts...); err == nil {
+		t.Error("second Init succeeded")
+	}
+	if err := closer(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, span := StartSpan(context.Background(), "op"); span.IsValid() {
+		t.Error("default tracer not reset by the closer")
+	}
+
+	closer, err = Init(context.Background(), "app", "1.0.0", opts...)
+	if err != nil {
+		t.Fatalf("Init after the closer: %v", err)
+	}
+	if err := closer(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewResource(t *testing.T) {
+	res := newResource("app", "1.0.0", buildOptions([]Option{
+		WithResourceAttributes(map[string]any{
+			"deployment.environment": "prod",
+			"service.name":           "other",
+			"unsupported":            struct{}{},
+		}),
+		WithResourceAttribute("host.cores", 8),
+	}))
+
+	want := map[attribute.Key]attribute.Value{
+		"deployment.environment":  attribute.StringValue("prod"),
+		"host.cores":              attribute.IntValue(8),
+		semconv.ServiceNameKey:    attribute.StringValue("app"),
+		semconv.ServiceVersionKey: attribute.StringValue("1.0.0"),
+	}
+	set := res.Set()
+	if set.Len() != len(want) {
+		t.Errorf("resource = %v, want %v", set.ToSlice(), want)
+	}
+	for key, value := range want {
+		if got, _ := set.Value(key); got != value {
+			t.Errorf("%s = %v, want %v", key, got.Emit(), value.Emit())
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+
+// Package tracertest contains utilities for testing instrumentation.
+package tracertest
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Node is the span in the trace tree. Trees are JSON serializable, so they can
+// be stored as golden files.
+type Node struct {
+	Name       string            `json:"name"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Children   []*Node           `json:"children,omitempty"`
+}
+
+// Trees builds trace trees from the recorded spans (e.g. by
+// tracetest.SpanRecorder), keeping only the attributes with keys. Spans whose
+// parent wasn't recorded are roots. Siblings are ordered by name and start time,
+// so the order doesn't depend on goroutine scheduling of same-named siblings.
+func Trees(spans []tracesdk.ReadOnlySpan, keys ...attribute.Key) []*Node {
+	var convert func(nodes []*spanNode) []*Node
+	convert = func(nodes []*spanNode) []*Node {
+		converted := make([]*Node, len(nodes))
+		for i, n := range nodes {
+			node := &Node{Name: n.span.Name(), Children: convert(n.children)}
+			for _, kv := range n.span.Attributes() {
+				if slices.Contains(keys, kv.Key) {
+					if node.Attributes == nil {
+						node.Attributes = make(map[string]string)
+					}
+					node.Attributes[string(kv.Key)] = kv.Value.Emit()
+				}
+			}
+			converted[i] = node
+		}
+		return converted
+	}
+
+	return convert(buildTree(spans))
+}
+
+// spanNode is the recorded span in the trace tree.
+type spanNode struct {
+	span     tracesdk.ReadOnlySpan
+	children []*spanNode
+}
+
+// buildTree builds trace trees from the recorded spans. Spans whose parent
+// wasn't recorded are roots. Siblings are ordered by name and start time.
+func buildTree(spans []tracesdk.ReadOnlySpan) []*spanNode {
+	nodes := make(map[trace.SpanID]*spanNode, len(spans))
+	for _, s := range spans {
+		nodes[s.SpanContext().SpanID()] = &spanNode{span: s}
+	}
+
+	var roots []*spanNode
+	for _, node := range nodes {
+		parent, ok := nodes[node.span.Parent().SpanID()]
+		if ok && node.span.Parent().TraceID() == node.span.SpanContext().TraceID() {
+			parent.children = append(parent.children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	var sortNodes func(nodes []*spanNode)
+	sortNodes = func(nodes []*spanNode) {
+		slices.SortFunc(nodes, func(a, b *spanNode) int {
+			return cmp.Or(
+				cmp.Compare(a.span.Name(), b.span.Name()),
+				a.span.StartTime().Compare(b.span.StartTime()),
+			)
+		})
+		for _, node := range nodes {
+			sortNodes(node.children)
+		}
+	}
+	sortNodes(roots)
+
+	return roots
+}
+
+// Diff reports the structural differences between the expected and the actual
+// trace trees: span names, hierarchy and attributes. It returns nil if the
+// trees match.
+func Diff(want, got []*Node) []string {
+	return diffNodes("", want, got)
+}
+
+func diffNodes(path string, want, got []*Node) []string {
+	var diffs []string
+	for i := range max(len(want), len(got)) {
+		switch {
+		case i >= len(got):
+			diffs = append(diffs, fmt.Sprintf("%s: missing span %q", path, want[i].Name))
+		case i >= len(want):
+			diffs = append(diffs, fmt.Sprintf("%s: unexpected span %q", path, got[i].Name))
+		default:
+			diffs = append(diffs, diffNode(fmt.Sprintf("%s/%s[%d]", path, want[i].Name, i), want[i], got[i])...)
+		}
+	}
+	return diffs
+}
+
+func diffNode(path string, want, got *Node) []string {
+	if want.Name != got.Name {
+		return []string{fmt.Sprintf("%s: name is %q", path, got.Name)}
+	}
+
+	var diffs []string
+	union := maps.Clone(want.Attributes)
+	if union == nil {
+		union = make(map[string]string, len(got.Attributes))
+	}
+	maps.Copy(union, got.Attributes)
+	for _, key := range slices.Sorted(maps.Keys(union)) {
+		wantValue, wantOK := want.Attributes[key]
+		gotValue, gotOK := got.Attributes[key]
+		switch {
+		case !gotOK:
+			diffs = append(diffs, fmt.Sprintf("%s: missing attribute %s=%q", path, key, wantValue))
+		case !wantOK:
+			diffs = append(diffs, fmt.Sprintf("%s: unexpected attribute %s=%q", path, key, gotValue))
+		case wantValue != gotValue:
+			diffs = append(diffs, fmt.Sprintf("%s: attribute %s is %q, want %q", path, key, gotValue, wantValue))
+		}
+	}
+
+	return append(diffs, diffNodes(path, want.Children, got.Children)...)
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+
+package tracertest
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// stub is the recorded span of testSpans.
+type stub struct {
+	name   string
+	id     byte
+	parent byte // 0 for roots
+	attrs  []attribute.KeyValue
+}
+
+// testSpans returns the recorded spans of one trace, started in order.
+func testSpans(stubs ...stub) []tracesdk.ReadOnlySpan {
+	traceID := trace.TraceID{1}
+	start := time.Unix(0, 0)
+
+	spans := make(tracetest.SpanStubs, len(stubs))
+	for i, s := range stubs {
+		spans[i] = tracetest.SpanStub{
+			Name: s.name,
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID, SpanID: trace.SpanID{s.id}, TraceFlags: trace.FlagsSampled,
+			}),
+			StartTime:  start.Add(time.Duration(i) * time.Millisecond),
+			EndTime:    start.Add(time.Second),
+			Attributes: s.attrs,
+		}
+		if s.parent != 0 {
+			spans[i].Parent = trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID, SpanID: trace.SpanID{s.parent}, TraceFlags: trace.FlagsSampled,
+			})
+		}
+	}
+	return spans.Snapshots()
+}
+
+func TestTrees(t *testing.T) {
+	spans := testSpans(
+		stub{name: "root", id: 1},
+		stub{name: "b", id: 2, parent: 1, attrs: []attribute.KeyValue{attribute.String("k", "v"), attribute.Int("skip", 1)}},
+		stub{name: "a", id: 3, parent: 1},
+		stub{name: "orphan", id: 4, parent: 9},
+	)
+
+	got := Trees(spans, "k")
+	want := []*Node{
+		{Name: "orphan"},
+		{Name: "root", Children: []*Node{
+			{Name: "a"},
+			{Name: "b", Attributes: map[string]string{"k": "v"}},
+		}},
+	}
+	if diffs := Diff(want, got); diffs != nil {
+		t.Errorf("trees differ: %v", diffs)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name      string
+		want, got []*Node
+		diffs     []string
+	}{
+		{
+			name: "equal",
+			want: []*Node{{Name: "a", Children: []*Node{{Name: "b"}}}},
+			got:  []*Node{{Name: "a", Children: []*Node{{Name: "b"}}}},
+		},
+		{
+			name:  "missing",
+			want:  []*Node{{Name: "a"}, {Name: "b"}},
+			got:   []*Node{{Name: "a"}},
+			diffs: []string{`: missing span "b"`},
+		},
+		{
+			name:  "unexpected",
+			want:  []*Node{{Name: "a"}},
+			got:   []*Node{{Name: "a", Children: []*Node{{Name: "c"}}}},
+			diffs: []string{`/a[0]: unexpected span "c"`},
+		},
+		{
+			name:  "renamed",
+			want:  []*Node{{Name: "a"}},
+			got:   []*Node{{Name: "b"}},
+			diffs: []string{`/a[0]: name is "b"`},
+		},
+		{
+			name: "attributes",
+			want: []*Node{{Name: "a", Attributes: map[string]string{"k1": "v", "k2": "v"}}},
+			got:  []*Node{{Name: "a", Attributes: map[string]string{"k2": "x", "k3": "v"}}},
+			diffs: []string{
+				`/a[0]: missing attribute k1="v"`,
+				`/a[0]: attribute k2 is "x", want "v"`,
+				`/a[0]: unexpected attribute k3="v"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diffs := Diff(tt.want, tt.got); !slices.Equal(diffs, tt.diffs) {
+				t.Errorf("diffs = %q, want %q", diffs, tt.diffs)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: MIT
+
+package tracertest
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// chromeEvent is the event of the Chrome trace event format.
+type chromeEvent struct {
+	Name  string            `json:"name"`
+	Phase string            `json:"ph"`
+	Scope string            `json:"s,omitempty"`
+	Time  float64           `json:"ts"`
+	Dur   *float64          `json:"dur,omitempty"`
+	PID   int               `json:"pid"`
+	TID   int               `json:"tid"`
+	Args  map[string]string `json:"args,omitempty"`
+}
+
+// WriteChromeTrace writes the recorded spans (e.g. by Spans or
+// tracetest.SpanRecorder) as the Chrome trace event format JSON, which can be
+// opened in chrome://tracing, Perfetto or speedscope to inspect the timeline of
+// a request as a flame chart without a tracing backend:
+//
+//	f, _ := os.Create("trace.json")
+//	defer f.Close()
+//	_ = tracertest.WriteChromeTrace(f, tracertest.Spans(t))
+//
+// Each trace is the process, concurrent spans are laid out on separate threads.
+// Span attributes and events are kept as event args and instant events.
+func WriteChromeTrace(w io.Writer, spans []tracesdk.ReadOnlySpan) error {
+	spans = slices.Clone(spans)
+	slices.SortStableFunc(spans, func(a, b tracesdk.ReadOnlySpan) int {
+		return cmp.Or(a.StartTime().Compare(b.StartTime()), b.EndTime().Compare(a.EndTime()))
+	})
+
+	var origin time.Time
+	if len(spans) > 0 {
+		origin = spans[0].StartTime()
+	}
+	micros := func(t time.Time) float64 {
+		return float64(t.Sub(origin).Nanoseconds()) / float64(time.Microsecond)
+	}
+
+	events := []chromeEvent{}
+	pids := make(map[trace.TraceID]int)
+	lanes := make(map[trace.TraceID][][]tracesdk.ReadOnlySpan) // stacks of open spans
+	for _, s := range spans {
+		traceID := s.SpanContext().TraceID()
+		pid, ok := pids[traceID]
+		if !ok {
+			pid = len(pids) + 1
+			pids[traceID] = pid
+			events = append(events, chromeEvent{
+				Name: "process_name", Phase: "M", PID: pid,
+				Args: map[string]string{"name": "trace " + traceID.String()},
+			})
+		}
+
+		tid := placeSpan(lanes[traceID], s)
+		if tid == len(lanes[traceID]) {
+			lanes[traceID] = append(lanes[traceID], nil)
+		}
+		lanes[traceID][tid] = append(lanes[traceID][tid], s)
+
+		dur := micros(s.EndTime()) - micros(s.StartTime())
+		events = append(events, chromeEvent{
+			Name: s.Name(), Phase: "X", Time: micros(s.StartTime()), Dur: &dur,
+			PID: pid, TID: tid + 1, Args: spanArgs(s),
+		})
+		for _, e := range s.Events() {
+			args := make(map[string]string, len(e.Attributes))
+			for _, kv := range e.Attributes {
+				args[string(kv.Key)] = kv.Value.Emit()
+			}
+			events = append(events, chromeEvent{
+				Name: e.Name, Phase: "i", Scope: "t", Time: micros(e.Time),
+				PID: pid, TID: tid + 1, Args: args,
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(map[string]any{"traceEvents": events, "displayTimeUnit": "ms"}); err != nil {
+		return fmt.Errorf("failed to write chrome trace: %w", err)
+	}
+	return nil
+}
+
+// placeSpan returns the first lane the span nests into: the spans ended before
+// it are popped from the lane stacks, and the top one must be the parent of the
+// span and end after it, so concurrent siblings don't nest. Returns len(lanes)
+// if the span needs a new lane.
+func placeSpan(lanes [][]tracesdk.ReadOnlySpan, s tracesdk.ReadOnlySpan) int {
+	for i, stack := range lanes {
+		for len(stack) > 0 && !stack[len(stack)-1].EndTime().After(s.StartTime()) {
+			stack = stack[:len(stack)-1]
+		}
+		lanes[i] = stack
+		if len(stack) == 0 {
+			return i
+		}
+		if top := stack[len(stack)-1]; top.SpanContext().SpanID() == s.Parent().SpanID() &&
+			!s.EndTime().After(top.EndTime()) {
+			return i
+		}
+	}
+	return len(lanes)
+}
+
+// spanArgs returns the span attributes and identity as event args.
+func spanArgs(s tracesdk.ReadOnlySpan) map[string]string {
+	args := map[string]string{
+		"span.id":   s.SpanContext().SpanID().String(),
+		"span.kind": s.SpanKind().String(),
+	}
+	if s.Parent().IsValid() {
+		args["span.parent_id"] = s.Parent().SpanID().String()
+	}
+	if status := s.Status(); status.Code != codes.Unset {
+		args["status"] = status.Code.String()
+		if status.Description != "" {
+			args["status.description"] = status.Description
+		}
+	}
+	for _, kv := range s.Attributes() {
+		args[string(kv.Key)] = kv.Value.Emit()
+	}
+	return args
+}
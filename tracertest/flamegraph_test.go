@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: MIT
+
+package tracertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWriteChromeTrace(t *testing.T) {
+	start := time.Unix(0, 0)
+	span := func(name string, id, parent byte, from, to time.Duration) tracetest.SpanStub {
+		s := tracetest.SpanStub{
+			Name:        name,
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{id}}),
+			StartTime:   start.Add(from),
+			EndTime:     start.Add(to),
+		}
+		if parent != 0 {
+			s.Parent = trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{parent}})
+		}
+		return s
+	}
+	root := span("request", 1, 0, 0, 100*time.Millisecond)
+	root.Events = []tracesdk.Event{{
+		Name: "retry", Time: start.Add(5 * time.Millisecond), Attributes: []attribute.KeyValue{attribute.Int("attempt", 2)},
+	}}
+	query := span("query", 2, 1, 10*time.Millisecond, 50*time.Millisecond)
+	query.Status = tracesdk.Status{Code: codes.Error, Description: "timeout"}
+	query.Attributes = []attribute.KeyValue{attribute.String("db.system", "postgresql")}
+	spans := tracetest.SpanStubs{
+		span("fetch", 3, 2, 15*time.Millisecond, 30*time.Millisecond), // nested in query
+		span("cache", 4, 1, 20*time.Millisecond, 60*time.Millisecond), // concurrent with query
+		root,
+		query,
+	}.Snapshots()
+
+	var buf bytes.Buffer
+	if err := WriteChromeTrace(&buf, spans); err != nil {
+		t.Fatal(err)
+	}
+	var out struct {
+		TraceEvents []chromeEvent `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	events := make(map[string]chromeEvent)
+	for _, e := range out.TraceEvents {
+		events[e.Name] = e
+	}
+	traceID := trace.TraceID{1}
+	if e := events["process_name"]; e.Phase != "M" || e.Args["name"] != "trace "+traceID.String() {
+		t.Errorf("process_name = %+v, want the trace metadata", e)
+	}
+	tests := []struct {
+		name  string
+		phase string
+		tid   int
+		time  float64
+	}{
+		{"request", "X", 1, 0},
+		{"retry", "i", 1, 5000},
+		{"query", "X", 1, 10000},
+		{"fetch", "X", 1, 15000},
+		{"cache", "X", 2, 20000},
+	}
+	for _, tt := range tests {
+		e := events[tt.name]
+		if e.Phase != tt.phase || e.TID != tt.tid || e.Time != tt.time || e.PID != 1 {
+			t.Errorf("%s = %s tid %d at %v, want %s tid %d at %v", tt.name, e.Phase, e.TID, e.Time, tt.phase, tt.tid, tt.time)
+		}
+	}
+	if e := events["query"]; e.Dur == nil || *e.Dur != 40000 {
+		t.Errorf("query duration = %v, want 40000", e.Dur)
+	}
+	want := map[string]string{
+		"status": "Error", "status.description": "timeout", "db.system": "postgresql", "span.parent_id": "0100000000000000",
+	}
+	for key, value := range want {
+		if got := events["query"].Args[key]; got != value {
+			t.Errorf("query arg %s = %q, want %q", key, got, value)
+		}
+	}
+	if got := events["retry"].Args["attempt"]; got != "2" {
+		t.Errorf("retry arg attempt = %q, want 2", got)
+	}
+}
+
+func TestWriteChromeTraceEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteChromeTrace(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != `{"displayTimeUnit":"ms","traceEvents":[]}`+"\n" {
+		t.Errorf("output = %q, want no events", got)
+	}
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: MIT
+
+package tracertest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+)
+
+var (
+	recordersMu sync.Mutex
+	recorders   = make(map[testing.TB]*tracetest.SpanRecorder)
+)
+
+// Init initializes the default tracer recording all spans in memory for the
+// test, and shuts it down at the test cleanup. Since the tracer is global, such
+// tests can't run in parallel.
+func Init(t testing.TB, opts ...tracer.Option) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	opts = append(opts,
+		tracer.WithExporter(tracetest.NewNoopExporter()),
+		tracer.WithSpanProcessor(recorder),
+		tracer.WithAlwaysSample(),
+	)
+	closer, err := tracer.Init(context.Background(), t.Name(), "test", opts...)
+	if err != nil {
+		t.Fatalf("failed to init tracer: %v", err)
+	}
+
+	recordersMu.Lock()
+	recorders[t] = recorder
+	recordersMu.Unlock()
+
+	t.Cleanup(func() {
+		recordersMu.Lock()
+		delete(recorders, t)
+		recordersMu.Unlock()
+
+		if err := closer(context.Background()); err != nil {
+			t.Errorf("failed to shutdown tracer: %v", err)
+		}
+	})
+}
+
+// Spans returns the spans ended in the test so far (see Init).
+func Spans(t testing.TB) []tracesdk.ReadOnlySpan {
+	t.Helper()
+
+	recordersMu.Lock()
+	recorder, ok := recorders[t]
+	recordersMu.Unlock()
+	if !ok {
+		t.Fatal("tracertest.Init wasn't called for the test")
+	}
+	return recorder.Ended()
+}
+
+// SpanAssertion asserts that there's an ended span with the name matching all
+// the checks:
+//
+//	tracertest.AssertSpan(t, "db.query").WithTag("db.system", "postgresql").WithStatus(codes.Error)
+type SpanAssertion struct {
+	t     testing.TB
+	name  string
+	spans []tracesdk.ReadOnlySpan
+	desc  string
+}
+
+// AssertSpan asserts that there's an ended span with the name.
+func AssertSpan(t testing.TB, name string) *SpanAssertion {
+	t.Helper()
+
+	a := &SpanAssertion{t: t, name: name, desc: fmt.Sprintf("span %q", name)}
+	for _, s := range Spans(t) {
+		if s.Name() == name {
+			a.spans = append(a.spans, s)
+		}
+	}
+	if len(a.spans) == 0 {
+		t.Errorf("no %s", a.desc)
+	}
+	return a
+}
+
+// WithTag asserts that the span has the attribute with the value converted as
+// by Span.Tag.
+func (a *SpanAssertion) WithTag(key string, value any) *SpanAssertion {
+	a.t.Helper()
+
+	want, ok := tracer.Convert(value)
+	if !ok {
+		a.t.Errorf("%s: unsupported value %v of tag %q", a.desc, value, key)
+		return a
+	}
+	return a.filter(fmt.Sprintf("tag %s=%q", key, want.Emit()), func(s tracesdk.ReadOnlySpan) bool {
+		for _, kv := range s.Attributes() {
+			if kv.Key == attribute.Key(key) {
+				return kv.Value == want
+			}
+		}
+		return false
+	})
+}
+
+// WithStatus asserts that the span has the status code.
+func (a *SpanAssertion) WithStatus(code codes.Code) *SpanAssertion {
+	a.t.Helper()
+
+	return a.filter("status "+code.String(), func(s tracesdk.ReadOnlySpan) bool {
+		return s.Status().Code == code
+	})
+}
+
+// filter keeps the spans matching the check, failing the test if there are
+// none. Checks after a failed one are skipped.
+func (a *SpanAssertion) filter(desc string, match func(tracesdk.ReadOnlySpan) bool) *SpanAssertion {
+	a.t.Helper()
+
+	if len(a.spans) == 0 {
+		return a
+	}
+
+	var matched []tracesdk.ReadOnlySpan
+	for _, s := range a.spans {
+		if match(s) {
+			matched = append(matched, s)
+		}
+	}
+	if len(matched) == 0 {
+		a.t.Errorf("no %s with %s", a.desc, desc)
+	}
+	a.spans = matched
+	a.desc += " with " + desc
+	return a
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+
+package tracertest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+)
+
+func TestAssertSpan(t *testing.T) {
+	tests := []struct {
+		name   string
+		assert func(t testing.TB)
+		errors []string
+	}{
+		{
+			name: "match",
+			assert: func(t testing.TB) {
+				AssertSpan(t, "query").WithTag("db.system", "postgresql").WithStatus(codes.Error)
+			},
+		},
+		{
+			name:   "missing span",
+			assert: func(t testing.TB) { AssertSpan(t, "other").WithTag("k", "v") },
+			errors: []string{`no span "other"`},
+		},
+		{
+			name:   "tag mismatch",
+			assert: func(t testing.TB) { AssertSpan(t, "query").WithTag("db.system", "mysql").WithStatus(codes.Ok) },
+			errors: []string{`no span "query" with tag db.system="mysql"`},
+		},
+		{
+			name:   "status mismatch",
+			assert: func(t testing.TB) { AssertSpan(t, "query").WithStatus(codes.Ok) },
+			errors: []string{`no span "query" with status Ok`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &failures{TB: t}
+			Init(f)
+
+			_, span := tracer.StartSpan(context.Background(), "query")
+			span.Tag("db.system", "postgresql")
+			err := errors.New("failed")
+			span.End(&err)
+
+			tt.assert(f)
+			if !slices.Equal(f.errors, tt.errors) {
+				t.Errorf("errors = %q, want %q", f.errors, tt.errors)
+			}
+		})
+	}
+}
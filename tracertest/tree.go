@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: MIT
+
+package tracertest
+
+import (
+	"fmt"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ExpectedSpan is the span of the expected trace tree:
+//
+//	tracertest.AssertTree(t, recorder.Ended(),
+//		tracertest.Expect("GET /orders",
+//			tracertest.Expect("db.query").WithAttr(attribute.String("db.system", "postgresql")),
+//			tracertest.Expect("cache.get"),
+//		),
+//	)
+type ExpectedSpan struct {
+	name     string
+	checks   []attributeCheck
+	children []*ExpectedSpan
+}
+
+type attributeCheck struct {
+	key   attribute.Key
+	desc  string
+	match func(attribute.Value) bool
+}
+
+// Expect returns the expected span with the name and children. The order of
+// children doesn't matter.
+func Expect(name string, children ...*ExpectedSpan) *ExpectedSpan {
+	return &ExpectedSpan{name: name, children: children}
+}
+
+// WithAttr expects the span to have the attribute.
+func (e *ExpectedSpan) WithAttr(kv attribute.KeyValue) *ExpectedSpan {
+	return e.WithAttrFunc(string(kv.Key), fmt.Sprintf("= %q", kv.Value.Emit()), func(v attribute.Value) bool {
+		return v == kv.Value
+	})
+}
+
+// WithAttrFunc expects the span to have the attribute matching the predicate.
+// desc describes the predicate in failure messages.
+func (e *ExpectedSpan) WithAttrFunc(key, desc string, match func(attribute.Value) bool) *ExpectedSpan {
+	e.checks = append(e.checks, attributeCheck{key: attribute.Key(key), desc: desc, match: match})
+	return e
+}
+
+// AssertTree asserts that the recorded spans form exactly the expected trees:
+// each expected span is matched by a recorded span with the same name, the
+// attributes matching the checks and the children matching the expected
+// children, and there are no other spans.
+func AssertTree(t testing.TB, spans []tracesdk.ReadOnlySpan, want ...*ExpectedSpan) {
+	t.Helper()
+
+	for _, diff := range matchSpans("", want, buildTree(spans)) {
+		t.Error(diff)
+	}
+}
+
+// matchSpans matches the expected spans with the recorded ones and returns the
+// mismatches.
+func matchSpans(path string, want []*ExpectedSpan, got []*spanNode) []string {
+	var diffs []string
+	used := make([]bool, len(got))
+
+	for _, e := range want {
+		// Prefer the exact match, otherwise report diffs of the first span with
+		// the same name.
+		candidate := -1
+		var candidateDiffs []string
+		for i, node := range got {
+			if used[i] || node.span.Name() != e.name {
+				continue
+			}
+			nodeDiffs := matchSpan(path+"/"+e.name, e, node)
+			if len(nodeDiffs) == 0 {
+				candidate, candidateDiffs = i, nil
+				break
+			}
+			if candidate < 0 {
+				candidate, candidateDiffs = i, nodeDiffs
+			}
+		}
+
+		if candidate < 0 {
+			diffs = append(diffs, fmt.Sprintf("%s: missing span %q", path, e.name))
+			continue
+		}
+		used[candidate] = true
+		diffs = append(diffs, candidateDiffs...)
+	}
+
+	for i, node := range got {
+		if !used[i] {
+			diffs = append(diffs, fmt.Sprintf("%s: unexpected span %q", path, node.span.Name()))
+		}
+	}
+	return diffs
+}
+
+func matchSpan(path string, want *ExpectedSpan, got *spanNode) []string {
+	var diffs []string
+	for _, check := range want.checks {
+		value, ok := attributeValue(got.span, check.key)
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("%s: missing attribute %s %s", path, check.key, check.desc))
+		case !check.match(value):
+			diffs = append(diffs, fmt.Sprintf("%s: attribute %s is %q, want %s", path, check.key, value.Emit(), check.desc))
+		}
+	}
+	return append(diffs, matchSpans(path, want.children, got.children)...)
+}
+
+func attributeValue(s tracesdk.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range s.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
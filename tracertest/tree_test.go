@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+
+package tracertest
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// failures records test failures instead of failing the test.
+type failures struct {
+	testing.TB
+
+	errors []string
+}
+
+func (f *failures) Helper() {}
+
+func (f *failures) Error(args ...any) {
+	f.errors = append(f.errors, fmt.Sprint(args...))
+}
+
+func (f *failures) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertTree(t *testing.T) {
+	spans := testSpans(
+		stub{name: "GET /orders", id: 1},
+		stub{name: "db.query", id: 2, parent: 1, attrs: []attribute.KeyValue{attribute.String("db.system", "postgresql")}},
+		stub{name: "db.query", id: 3, parent: 1, attrs: []attribute.KeyValue{attribute.String("db.system", "redis")}},
+	)
+
+	tests := []struct {
+		name   string
+		want   *ExpectedSpan
+		errors []string
+	}{
+		{
+			name: "match in any order",
+			want: Expect("GET /orders",
+				Expect("db.query").WithAttr(attribute.String("db.system", "redis")),
+				Expect("db.query").WithAttr(attribute.String("db.system", "postgresql")),
+			),
+		},
+		{
+			name: "attribute predicate",
+			want: Expect("GET /orders",
+				Expect("db.query"),
+				Expect("db.query").WithAttrFunc("db.system", "is redis", func(v attribute.Value) bool {
+					return v.AsString() == "redis"
+				}),
+			),
+		},
+		{
+			name: "missing and unexpected",
+			want: Expect("GET /orders", Expect("db.query"), Expect("cache.get")),
+			errors: []string{
+				`/GET /orders: missing span "cache.get"`,
+				`/GET /orders: unexpected span "db.query"`,
+			},
+		},
+		{
+			name: "attribute mismatch",
+			want: Expect("GET /orders",
+				Expect("db.query").WithAttr(attribute.String("db.system", "mysql")),
+				Expect("db.query").WithAttr(attribute.String("db.name", "orders")),
+			),
+			errors: []string{
+				`/GET /orders/db.query: attribute db.system is "postgresql", want = "mysql"`,
+				`/GET /orders/db.query: missing attribute db.name = "orders"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &failures{TB: t}
+			AssertTree(f, spans, tt.want)
+			if !slices.Equal(f.errors, tt.errors) {
+				t.Errorf("errors = %q, want %q", f.errors, tt.errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"crypto/subtle"
+	"net"
+	"net/netip"
+)
+
+// Inbound describes the caller of an inbound request for TrustPolicy.
+type Inbound struct {
+	// RemoteAddr is the caller network address ("host:port").
+	RemoteAddr string
+
+	// Header returns the request header (HTTP) or metadata (gRPC) value.
+	Header func(key string) string
+}
+
+// TrustPolicy reports whether the trace context extracted from the inbound
+// request is honored. If it isn't, the server span becomes a new root linked to
+// the remote parent.
+type TrustPolicy func(in Inbound) bool
+
+// WithInboundTrustPolicy sets the policy governing whether remote parents
+// extracted by Middleware and server interceptors are honored. By default all
+// callers are trusted.
+func WithInboundTrustPolicy(policy TrustPolicy) Option {
+	return func(opts *Options) {
+		opts.trustPolicy = policy
+	}
+}
+
+// TrustAll trusts all callers.
+func TrustAll() TrustPolicy {
+	return func(Inbound) bool {
+		return true
+	}
+}
+
+// TrustNone trusts no callers: every inbound request starts a new trace.
+func TrustNone() TrustPolicy {
+	return func(Inbound) bool {
+		return false
+	}
+}
+
+// TrustCIDRs trusts callers from the networks.
+func TrustCIDRs(prefixes ...netip.Prefix) TrustPolicy {
+	return func(in Inbound) bool {
+		host, _, err := net.SplitHostPort(in.RemoteAddr)
+		if err != nil {
+			host = in.RemoteAddr
+		}
+		addr, err := netip.ParseAddr(host)
+		if err != nil {
+			return false
+		}
+
+		addr = addr.Unmap()
+		for _, prefix := range prefixes {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// TrustHeader trusts callers authenticated by the shared secret passed in the
+// header (or gRPC metadata) key.
+func TrustHeader(key, secret string) TrustPolicy {
+	return func(in Inbound) bool {
+		if in.Header == nil {
+			return false
+		}
+		return subtle.ConstantTimeCompare([]byte(in.Header(key)), []byte(secret)) == 1
+	}
+}
+
+// TrustAny trusts callers trusted by any of the policies.
+func TrustAny(policies ...TrustPolicy) TrustPolicy {
+	return func(in Inbound) bool {
+		for _, policy := range policies {
+			if policy(in) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// trustsInbound applies the policy set by WithInboundTrustPolicy for the default
+// tracer.
+func trustsInbound(in Inbound) bool {
+	policy := current().options.trustPolicy
+	return policy == nil || policy(in)
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+
+package tracer_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+	"github.com/cdnnow-pro/go-tracer/tracertest"
+)
+
+func TestMiddlewareRequestIDTrust(t *testing.T) {
+	const requestID = "0af7651916cd43dd8448eb211c80319c"
+
+	tests := []struct {
+		name    string
+		policy  tracer.TrustPolicy
+		derived bool
+	}{
+		{"default", nil, true},
+		{"trusted", tracer.TrustAll(), true},
+		{"untrusted", tracer.TrustNone(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []tracer.Option{tracer.WithRequestIDFallback()}
+			if tt.policy != nil {
+				opts = append(opts, tracer.WithInboundTrustPolicy(tt.policy))
+			}
+			tracertest.Init(t, opts...)
+
+			var traceID string
+			handler := tracer.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				traceID = tracer.SpanFromContext(r.Context()).TraceId()
+			}))
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("X-Request-ID", requestID)
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			if derived := traceID == requestID; derived != tt.derived {
+				t.Errorf("trace ID = %s, want derived from the request ID %v", traceID, tt.derived)
+			}
+		})
+	}
+}
+
+func TestTrustPolicies(t *testing.T) {
+	header := func(h map[string]string) func(string) string {
+		return func(key string) string { return h[key] }
+	}
+	cidrs := tracer.TrustCIDRs(netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("fd00::/8"))
+	secret := tracer.TrustHeader("X-Trace-Auth", "s3cret")
+
+	tests := []struct {
+		name   string
+		policy tracer.TrustPolicy
+		in     tracer.Inbound
+		want   bool
+	}{
+		{"all", tracer.TrustAll(), tracer.Inbound{}, true},
+		{"none", tracer.TrustNone(), tracer.Inbound{RemoteAddr: "10.0.0.1:80"}, false},
+		{"cidr", cidrs, tracer.Inbound{RemoteAddr: "10.1.2.3:80"}, true},
+		{"cidr without port", cidrs, tracer.Inbound{RemoteAddr: "10.1.2.3"}, true},
+		{"cidr mapped IPv4", cidrs, tracer.Inbound{RemoteAddr: "[::ffff:10.1.2.3]:80"}, true},
+		{"cidr IPv6", cidrs, tracer.Inbound{RemoteAddr: "[fd12::1]:80"}, true},
+		{"cidr outside", cidrs, tracer.Inbound{RemoteAddr: "192.0.2.1:80"}, false},
+		{"cidr invalid", cidrs, tracer.Inbound{RemoteAddr: "pipe"}, false},
+		{"header", secret, tracer.Inbound{Header: header(map[string]string{"X-Trace-Auth": "s3cret"})}, true},
+		{"header mismatch", secret, tracer.Inbound{Header: header(map[string]string{"X-Trace-Auth": "guess"})}, false},
+		{"header missing", secret, tracer.Inbound{Header: header(nil)}, false},
+		{"header unavailable", secret, tracer.Inbound{}, false},
+		{"any", tracer.TrustAny(cidrs, secret), tracer.Inbound{RemoteAddr: "10.0.0.1:80"}, true},
+		{"any none", tracer.TrustAny(cidrs, secret), tracer.Inbound{RemoteAddr: "192.0.2.1:80"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy(tt.in); got != tt.want {
+				t.Errorf("policy(%+v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddlewareTrustPolicy(t *testing.T) {
+	const traceID = "0102030405060708090a0b0c0d0e0f10"
+
+	tests := []struct {
+		name   string
+		policy tracer.TrustPolicy
+		child  bool
+	}{
+		{"default", nil, true},
+		{"trusted", tracer.TrustCIDRs(netip.MustParsePrefix("192.0.2.0/24")), true},
+		{"untrusted", tracer.TrustNone(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []tracer.Option
+			if tt.policy != nil {
+				opts = append(opts, tracer.WithInboundTrustPolicy(tt.policy))
+			}
+			tracertest.Init(t, opts...)
+
+			handler := tracer.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Traceparent", "00-"+traceID+"-0102030405060708-01")
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			spans := tracertest.Spans(t)
+			if len(spans) != 1 {
+				t.Fatalf("ended %d spans, want 1", len(spans))
+			}
+			s := spans[0]
+			if child := s.SpanContext().TraceID().String() == traceID; child != tt.child {
+				t.Errorf("continues the remote trace = %v, want %v", child, tt.child)
+			}
+			if linked := len(s.Links()) == 1; linked == tt.child {
+				t.Errorf("linked to the remote parent = %v, want %v", linked, !tt.child)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+// Warning is the problem of the options found by ValidateOptions.
+type Warning struct {
+	// Code identifies the problem, e.g. "noop-exporter".
+	Code    string
+	Message string
+}
+
+func (w Warning) String() string {
+	return w.Code + ": " + w.Message
+}
+
+// ValidateOptions reports options that are invalid (Init fails) or contradict
+// each other, so one of them has no effect, e.g. Noop with a collector endpoint.
+// It makes no connections, so platform tooling can check service configs before
+// deploy. With WithEnvConfig the environment is validated too.
+func ValidateOptions(opts ...Option) []Warning {
+	o := buildOptions(opts)
+
+	var warnings []Warning
+	warn := func(code, message string) {
+		warnings = append(warnings, Warning{Code: code, Message: message})
+	}
+
+	if o.encryption != nil && o.encryption.err != nil {
+		warn("invalid-encryption", o.encryption.err.Error())
+	}
+	if o.pipeline != nil && o.pipeline.err != nil {
+		warn("invalid-pipeline", o.pipeline.err.Error())
+	}
+
+	collector := o.endpoint != "" || o.host != "localhost" || o.port != 0
+	if o.noop {
+		if collector || o.httpExporter || o.localExporter != nil ||
+			len(o.additionalExporters) > 0 || o.auditTrail != nil {
+			warn("noop-exporter", "Noop disables the tracer, the exporter options have no effect")
+		}
+		return warnings
+	}
+
+	switch {
+	case o.localExporter != nil && (collector || o.httpExporter):
+		warn("local-exporter-collector",
+			"spans are exported locally (WithExporter, WithStdoutExporter or WithFileExporter), the collector options have no effect")
+	case o.httpExporter && o.endpoint != "":
+		warn("http-exporter-endpoint",
+			"WithCollectorEndpoint sets the gRPC target, the HTTP exporter uses WithCollectorHost and WithCollectorPort")
+	}
+
+	if o.cliMode {
+		if o.batcher != (batcherConfig{}) || o.flushInterval > 0 {
+			warn("sync-export-batching", "WithCLIMode exports spans without batching, the batch options have no effect")
+		}
+		if o.criticalLane {
+			warn("sync-export-critical-lane", "WithCLIMode exports spans without batching, WithCriticalLane has no effect")
+		}
+		if o.serverless {
+			warn("sync-export-serverless", "WithCLIMode exports spans without batching, WithServerlessMode has no effect")
+		}
+	}
+	if o.queueTelemetry && (o.cliMode || o.criticalLane) {
+		warn("queue-telemetry-ignored", "WithQueueTelemetry has no effect with WithCLIMode and WithCriticalLane")
+	}
+	if !o.cliMode && o.batcher.maxExportBatchSize > batchSnapshot(o).MaxQueueSize {
+		warn("batch-exceeds-queue", "the max export batch size exceeds the max queue size and is capped by it")
+	}
+	if o.defaultLogger && o.logs == nil {
+		warn("default-logger-without-logs", "WithDefaultLogger has no effect without WithLogs")
+	}
+	if o.lazyConnect && o.startupTimeout > 0 {
+		warn("lazy-connect-startup-timeout", "WithLazyConnect skips the connection on startup, WithStartupTimeout has no effect")
+	}
+
+	return warnings
+}
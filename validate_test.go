@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestValidateOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []Option
+		codes []string
+	}{
+		{"defaults", nil, nil},
+		{"noop", []Option{Noop()}, nil},
+		{"noop with collector", []Option{Noop(), WithCollectorHost("collector")}, []string{"noop-exporter"}},
+		{"invalid encryption", []Option{WithAttributeEncryption("k1", []byte("short"), "user.id")}, []string{"invalid-encryption"}},
+		{"local exporter with collector", []Option{WithStdoutExporter(), WithCollectorPort(4318)}, []string{"local-exporter-collector"}},
+		{"http exporter with endpoint", []Option{WithHTTPExporter(), WithCollectorEndpoint("dns:///agent:4317")}, []string{"http-exporter-endpoint"}},
+		{
+			"cli mode",
+			[]Option{WithCLIMode(), WithBatchTimeout(time.Second), WithCriticalLane(), WithServerlessMode(), WithQueueTelemetry()},
+			[]string{"sync-export-batching", "sync-export-critical-lane", "sync-export-serverless", "queue-telemetry-ignored"},
+		},
+		{"batch exceeds queue", []Option{WithMaxQueueSize(10), WithMaxExportBatchSize(100)}, []string{"batch-exceeds-queue"}},
+		{"default logger without logs", []Option{WithDefaultLogger()}, []string{"default-logger-without-logs"}},
+		{"lazy connect", []Option{WithLazyConnect(), WithStartupTimeout(time.Second)}, []string{"lazy-connect-startup-timeout"}},
+		{"custom exporter", []Option{WithExporter(tracetest.NewNoopExporter()), WithAlwaysSample()}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var codes []string
+			for _, w := range ValidateOptions(tt.opts...) {
+				codes = append(codes, w.Code)
+				if w.Message == "" {
+					t.Errorf("%s: empty message", w.Code)
+				}
+			}
+			if !slices.Equal(codes, tt.codes) {
+				t.Errorf("warnings = %v, want %v", codes, tt.codes)
+			}
+		})
+	}
+}
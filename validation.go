@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// WithAttributeValidation enables the development mode validator that warns
+// (once per key) about attributes with non-semconv keys close to the known ones,
+// e.g. "http.status" instead of "http.response.status_code", and about keys used
+// with different value types across spans.
+func WithAttributeValidation() Option {
+	return func(opts *Options) {
+		opts.attributeValidation = true
+	}
+}
+
+// maxKeyDistance is the max edit distance between a key and a known key for
+// the key to be reported as a likely misspelling.
+const maxKeyDistance = 2
+
+// knownAttributeKeys are the semconv keys keys are checked against.
+var knownAttributeKeys = []string{
+	"http.request.method",
+	"http.response.status_code",
+	"http.route",
+	"url.full",
+	"url.path",
+	"url.query",
+	"url.scheme",
+	"server.address",
+	"server.port",
+	"client.address",
+	"client.port",
+	"user_agent.original",
+	"network.protocol.name",
+	"network.protocol.version",
+	"rpc.system",
+	"rpc.service",
+	"rpc.method",
+	"rpc.grpc.status_code",
+	"db.system",
+	"db.namespace",
+	"db.operation.name",
+	"db.query.text",
+	"db.collection.name",
+	"messaging.system",
+	"messaging.destination.name",
+	"messaging.operation.type",
+	"messaging.message.id",
+	"error.type",
+	"exception.type",
+	"exception.message",
+	"exception.stacktrace",
+	"code.function",
+	"code.filepath",
+	"code.lineno",
+	"code.stacktrace",
+	"enduser.id",
+	"service.name",
+	"service.version",
+	"deployment.environment",
+}
+
+// deprecatedAttributeKeys maps old or commonly misused keys to the current ones.
+var deprecatedAttributeKeys = map[string]string{
+	"http.method":      "http.request.method",
+	"http.status":      "http.response.status_code",
+	"http.status_code": "http.response.status_code",
+	"http.url":         "url.full",
+	"http.target":      "url.path",
+	"http.scheme":      "url.scheme",
+	"http.user_agent":  "user_agent.original",
+	"net.peer.name":    "server.address",
+	"net.peer.port":    "server.port",
+	"net.host.name":    "server.address",
+	"db.statement":     "db.query.text",
+	"db.operation":     "db.operation.name",
+	"db.name":          "db.namespace",
+	"error.message":    "exception.message",
+}
+
+// validationProcessor checks attributes of ended spans.
+type validationProcessor struct {
+	known map[string]struct{}
+
+	mu     sync.Mutex
+	types  map[attribute.Key]attribute.Type
+	warned map[attribute.Key]struct{}
+}
+
+var _ tracesdk.SpanProcessor = (*validationProcessor)(nil)
+
+func newValidationProcessor() *validationProcessor {
+	known := make(map[string]struct{}, len(knownAttributeKeys))
+	for _, key := range knownAttributeKeys {
+		known[key] = struct{}{}
+	}
+
+	return &validationProcessor{
+		known:  known,
+		types:  make(map[attribute.Key]attribute.Type),
+		warned: make(map[attribute.Key]struct{}),
+	}
+}
+
+func (*validationProcessor) OnStart(context.Context, tracesdk.ReadWriteSpan) {}
+
+func (p *validationProcessor) OnEnd(s tracesdk.ReadOnlySpan) {
+	for _, kv := range s.Attributes() {
+		p.checkType(s.Name(), kv)
+		p.checkKey(s.Name(), kv.Key)
+	}
+}
+
+func (*validationProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (*validationProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (p *validationProcessor) checkType(spanName string, kv attribute.KeyValue) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	seen, ok := p.types[kv.Key]
+	if !ok {
+		p.types[kv.Key] = kv.Value.Type()
+		return
+	}
+	if seen != kv.Value.Type() && p.warnOnce(kv.Key) {
+		slog.Warn("tracer: attribute used with different types",
+			slog.String("key", string(kv.Key)),
+			slog.String("span", spanName),
+			slog.String("type", kv.Value.Type().String()),
+			slog.String("previous_type", seen.String()),
+		)
+	}
+}
+
+func (p *validationProcessor) checkKey(spanName string, key attribute.Key) {
+	if _, ok := p.known[string(key)]; ok {
+		return
+	}
+
+	suggestion, ok := deprecatedAttributeKeys[string(key)]
+	if !ok {
+		suggestion, ok = p.closestKnown(string(key))
+	}
+	if !ok {
+		return
+	}
+
+	p.mu.Lock()
+	warn := p.warnOnce(key)
+	p.mu.Unlock()
+
+	if warn {
+		slog.Warn("tracer: non-semconv attribute key",
+			slog.String("key", string(key)),
+			slog.String("span", spanName),
+			slog.String("suggestion", suggestion),
+		)
+	}
+}
+
+// warnOnce reports whether the key wasn't warned about yet. Must be called with
+// p.mu held.
+func (p *validationProcessor) warnOnce(key attribute.Key) bool {
+	if _, ok := p.warned[key]; ok {
+		return false
+	}
+	p.warned[key] = struct{}{}
+	return true
+}
+
+func (p *validationProcessor) closestKnown(key string) (string, bool) {
+	for _, known := range knownAttributeKeys {
+		if editDistance(key, known) <= maxKeyDistance {
+			return known, true
+		}
+	}
+	return "", false
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"url.path", "url.path", 0},
+		{"url.pth", "url.path", 1},
+		{"rpc.methd", "rpc.method", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestAttributeValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		attrs [][]attribute.KeyValue // per span
+		want  []string               // logged fragments, none if empty
+	}{
+		{"known", [][]attribute.KeyValue{{attribute.String("url.path", "/")}}, nil},
+		{"custom", [][]attribute.KeyValue{{attribute.String("order.id", "1")}}, nil},
+		{"deprecated", [][]attribute.KeyValue{{attribute.Int("http.status", 200)}},
+			[]string{"non-semconv attribute key", "key=http.status", "suggestion=http.response.status_code"}},
+		{"misspelled", [][]attribute.KeyValue{{attribute.String("url.pth", "/")}},
+			[]string{"non-semconv attribute key", "key=url.pth", "suggestion=url.path"}},
+		{"mixed types", [][]attribute.KeyValue{{attribute.Int("order.id", 1)}, {attribute.String("order.id", "1")}},
+			[]string{"attribute used with different types", "key=order.id", "type=STRING", "previous_type=INT64"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestTracer(t, WithAttributeValidation())
+			logs := captureLogs(t)
+
+			for _, attrs := range tt.attrs {
+				_, span := StartSpan(context.Background(), "op", trace.WithAttributes(attrs...))
+				span.End()
+			}
+
+			if len(tt.want) == 0 && logs.Len() > 0 {
+				t.Errorf("logged %q, want nothing", logs)
+			}
+			for _, fragment := range tt.want {
+				if !strings.Contains(logs.String(), fragment) {
+					t.Errorf("logged %q, want %q", logs, fragment)
+				}
+			}
+		})
+	}
+}
+
+func TestAttributeValidationWarnsOnce(t *testing.T) {
+	initTestTracer(t, WithAttributeValidation())
+	logs := captureLogs(t)
+
+	for range 3 {
+		_, span := StartSpan(context.Background(), "op", trace.WithAttributes(attribute.String("http.method", "GET")))
+		span.End()
+	}
+
+	if n := strings.Count(logs.String(), "key=http.method"); n != 1 {
+		t.Errorf("warned %d times, want 1", n)
+	}
+}
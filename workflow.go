@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const traceparentHeader = "traceparent"
+
+// Workflow is a multi-step workflow (saga) traced as a single trace. Steps may
+// run in different processes: the workflow is persisted with the workflow state
+// (e.g. in the outbox or saga DB row) and restored before the next step.
+//
+// Workflow implements driver.Valuer and sql.Scanner, so it can be stored in a
+// text column directly.
+type Workflow struct {
+	sc trace.SpanContext
+}
+
+var (
+	_ driver.Valuer = Workflow{}
+	_ sql.Scanner   = (*Workflow)(nil)
+)
+
+// StartWorkflow starts the root span of the workflow. Steps started with
+// Workflow.StartStep become its children.
+func StartWorkflow(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, *span, Workflow) {
+	opts = append(opts, trace.WithAttributes(attribute.String("workflow.name", name)))
+	ctx, span := StartSpan(ctx, name, opts...)
+
+	return ctx, span, Workflow{sc: span.s.SpanContext()}
+}
+
+// ParseWorkflow restores the workflow persisted with Workflow.String.
+func ParseWorkflow(s string) (Workflow, error) {
+	carrier := propagation.MapCarrier{traceparentHeader: s}
+	sc := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), carrier))
+	if !sc.IsValid() {
+		return Workflow{}, fmt.Errorf("invalid workflow span context %q", s)
+	}
+
+	return Workflow{sc: sc}, nil
+}
+
+// StartStep starts a span of the workflow step. The span is a child of the
+// workflow span, and it's linked to the span from ctx (if any), e.g. the span of
+// the job that executes the step.
+func (w Workflow) StartStep(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, *span) {
+	if local := trace.SpanContextFromContext(ctx); local.IsValid() && !local.Equal(w.sc) {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: local}))
+	}
+	opts = append(opts, trace.WithAttributes(attribute.String("workflow.step", name)))
+
+	return StartSpan(trace.ContextWithSpanContext(ctx, w.sc), name, opts...)
+}
+
+// IsValid returns if the workflow has a valid span context.
+func (w Workflow) IsValid() bool {
+	return w.sc.IsValid()
+}
+
+// String returns the workflow span context in the W3C traceparent format.
+func (w Workflow) String() string {
+	if !w.sc.IsValid() {
+		return ""
+	}
+
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(trace.ContextWithSpanContext(context.Background(), w.sc), carrier)
+
+	return carrier.Get(traceparentHeader)
+}
+
+// Value implements driver.Valuer. An invalid workflow is stored as NULL.
+func (w Workflow) Value() (driver.Value, error) {
+	if !w.sc.IsValid() {
+		return nil, nil //nolint:nilnil
+	}
+	return w.String(), nil
+}
+
+// Scan implements sql.Scanner.
+func (w *Workflow) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*w = Workflow{}
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return errors.New("unsupported workflow column type")
+	}
+
+	parsed, err := ParseWorkflow(s)
+	if err != nil {
+		return err
+	}
+	*w = parsed
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: MIT
+
+package tracer
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWorkflowSteps(t *testing.T) {
+	recorder := initTestTracer(t)
+
+	_, root, w := StartWorkflow(context.Background(), "order")
+	root.End()
+
+	// The step runs in another process, within the span of the job.
+	restored, err := ParseWorkflow(w.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobCtx, job := StartSpan(context.Background(), "job")
+	_, step := restored.StartStep(jobCtx, "charge")
+	step.End()
+	job.End()
+
+	spans := recorder.Ended()
+	rootSpan, stepSpan, jobSpan := spans[0], spans[1], spans[2]
+	if v, _ := spanAttribute(rootSpan, "workflow.name"); v.AsString() != "order" {
+		t.Errorf("workflow.name = %q, want order", v.AsString())
+	}
+	if v, _ := spanAttribute(stepSpan, "workflow.step"); v.AsString() != "charge" {
+		t.Errorf("workflow.step = %q, want charge", v.AsString())
+	}
+	if !stepSpan.Parent().Equal(rootSpan.SpanContext().WithRemote(true)) {
+		t.Errorf("step parent = %v, want the workflow span %v", stepSpan.Parent(), rootSpan.SpanContext())
+	}
+	if links := stepSpan.Links(); len(links) != 1 || !links[0].SpanContext.Equal(jobSpan.SpanContext()) {
+		t.Errorf("step links = %v, want the job span", links)
+	}
+}
+
+func TestWorkflowScan(t *testing.T) {
+	initTestTracer(t)
+	_, span, w := StartWorkflow(context.Background(), "order")
+	span.End()
+
+	tests := []struct {
+		name    string
+		src     any
+		valid   bool
+		wantErr bool
+	}{
+		{"string", w.String(), true, false},
+		{"bytes", []byte(w.String()), true, false},
+		{"null", nil, false, false},
+		{"invalid", "00-invalid", false, true},
+		{"unsupported type", 42, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var scanned Workflow
+			err := scanned.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if scanned.IsValid() != tt.valid {
+				t.Fatalf("valid = %v, want %v", scanned.IsValid(), tt.valid)
+			}
+
+			value, err := scanned.Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.valid && value != w.String() {
+				t.Errorf("Value() = %v, want %q", value, w.String())
+			}
+			if !tt.valid && value != nil {
+				t.Errorf("Value() = %v, want nil", value)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: MIT
+
+// Package zaptracer correlates zap logs with traces:
+//
+//	logger.Info("done", zaptracer.Fields(ctx)...)
+package zaptracer
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+)
+
+// Fields returns the "trace_id" and "span_id" fields of the span of ctx, or nil
+// if ctx has no sampled span (see tracer.LogFields).
+func Fields(ctx context.Context) []zap.Field {
+	attrs := tracer.LogFields(ctx)
+	if attrs == nil {
+		return nil
+	}
+	fields := make([]zap.Field, len(attrs))
+	for i, attr := range attrs {
+		fields[i] = zap.String(attr.Key, attr.Value.String())
+	}
+	return fields
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT
+
+package zaptracer_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	tracer "github.com/cdnnow-pro/go-tracer"
+	"github.com/cdnnow-pro/go-tracer/tracertest"
+	"github.com/cdnnow-pro/go-tracer/zaptracer"
+)
+
+func TestFields(t *testing.T) {
+	tracertest.Init(t)
+	ctx, span := tracer.StartSpan(context.Background(), "request")
+	defer span.End()
+	unsampled := trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx).WithTraceFlags(0))
+
+	tests := []struct {
+		name   string
+		ctx    context.Context //nolint:containedctx
+		fields map[string]any
+	}{
+		{"sampled", ctx, map[string]any{"trace_id": span.TraceId(), "span_id": span.SpanId()}},
+		{"unsampled", unsampled, map[string]any{}},
+		{"no span", context.Background(), map[string]any{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.InfoLevel)
+			zap.New(core).Info("done", zaptracer.Fields(tt.ctx)...)
+
+			fields := logs.All()[0].ContextMap()
+			if len(fields) != len(tt.fields) {
+				t.Errorf("fields = %v, want %v", fields, tt.fields)
+			}
+			for key, want := range tt.fields {
+				if fields[key] != want {
+					t.Errorf("%s = %v, want %v", key, fields[key], want)
+				}
+			}
+		})
+	}
+}